@@ -0,0 +1,125 @@
+package titlecase
+
+import (
+ "unicode"
+)
+
+// Heuristics used to detect acronyms that are not in the caps dictionary
+const (
+ Acronym_NoVowels		= 1 << iota // contains no vowels, e.g. NSDAP
+ Acronym_Cluster					// typed in uppercase and begins or ends with consonants that cannot be together in English, e.g. NAACP
+ Acronym_UpperInMixed				// typed in uppercase in otherwise mixed case input
+ Acronym_Short						// short word typed in uppercase
+ Acronym_VowelPair					// short word typed in uppercase ending with two vowels that do not end English words, e.g. CIO
+)
+
+// Default confidence needed for a word to be uppercased as an acronym
+const DefaultAcronymThreshold = 0.5
+
+// Acronym is a word that was uppercased because of the heuristics
+type Acronym struct {
+ Word string
+ Heuristics uint8 // Acronym_ flags of the heuristics that fired
+ Confidence float64
+}
+
+// Words that look like acronyms but are not
+var acronymExceptions = []string{`beau`, `bio`, `brr`, `cf`, `ciao`, `cwm`, `duo`, `frau`, `geo`, `grr`, `hmm`, `jr`, `lei`, `leo`, `ltd`, `mr`, `mrs`, `ms`, `neo`, `nth`, `pfft`, `pp`,
+ `psst`, `shh`, `sr`, `st`, `tao`, `trio`, `tsk`, `via`, `vs`, `zzz`}
+
+// Vowel pairs that rarely end a short English word
+var unusualEndings = []string{`ao`, `au`, `ei`, `eo`, `ia`, `ii`, `io`, `iu`, `ua`, `uo`, `uu`}
+
+// Consonant pairs that can begin and end an English word
+var englishOnsets = []string{`bl`, `br`, `ch`, `cl`, `cr`, `dj`, `dr`, `dw`, `fl`, `fr`, `gh`, `gl`, `gn`, `gr`, `gw`, `kh`, `kl`, `kn`, `kr`, `kw`, `ph`, `pl`, `pn`, `pr`, `ps`, `pt`,
+ `rh`, `sc`, `sh`, `sk`, `sl`, `sm`, `sn`, `sp`, `st`, `sv`, `sw`, `th`, `tr`, `ts`, `tw`, `vl`, `wh`, `wr`, `zh`}
+var englishCodas = []string{`bs`, `bt`, `cs`, `ch`, `ck`, `ct`, `dd`, `ds`, `ff`, `fs`, `ft`, `gg`, `gh`, `gm`, `gn`, `gs`, `hm`, `hs`, `ht`, `ks`, `lb`, `lc`, `ld`, `lf`, `lk`, `ll`, `lm`, `ln`,
+ `lp`, `ls`, `lt`, `mb`, `mn`, `mp`, `ms`, `nc`, `nd`, `ng`, `nk`, `nn`, `ns`, `nt`, `nx`, `ph`, `ps`, `pt`, `rb`, `rc`, `rd`, `rf`, `rg`, `rk`, `rl`, `rm`, `rn`, `rp`, `rr`, `rs`, `rt`,
+ `sc`, `sh`, `sk`, `sm`, `sp`, `ss`, `st`, `th`, `ts`, `tt`, `tz`, `wd`, `wk`, `wl`, `wn`, `ws`, `wt`, `xt`, `zz`}
+
+func isVowel(r rune) bool {
+	switch unicode.ToLower(r) {
+		case 'a', 'e', 'i', 'o', 'u', 'y', 'à', 'á', 'â', 'ä', 'ã', 'å', 'æ', 'è', 'é', 'ê', 'ë', 'ì', 'í', 'î', 'ï', 'ò', 'ó', 'ô', 'ö', 'õ', 'ø', 'ù', 'ú', 'û', 'ü', 'ý', 'ÿ', 'œ':
+			return true
+	}
+	return false
+}
+
+func inList(list []string, word string) bool {
+	for _, w := range list {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether all the letters in a word are uppercase, and there are at least 2
+func isAllUpper(word []rune) bool {
+	var n int
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsUpper(r) {
+			n++
+		}
+	}
+	return n > 1
+}
+
+// Scores how likely it is that a word not in the dictionaries is an acronym, returning the heuristics that fired and the combined confidence
+func acronymScore(word []rune, original []rune, language uint8, mixedInput bool) (uint8, float64) {
+	if len(word) < 2 {
+		return 0, 0
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return 0, 0
+		}
+	}
+	if inList(acronymExceptions, string(word)) {
+		return 0, 0
+	}
+	var heuristics uint8
+	doubt := 1.0 // the chance it is not an acronym
+	upper := isAllUpper(original)
+
+	if upper && mixedInput {
+		heuristics |= Acronym_UpperInMixed
+		doubt *= 0.1
+	}
+
+	vowels := false
+	for _, r := range word {
+		if isVowel(r) {
+			vowels = true
+			break
+		}
+	}
+	if !vowels {
+		heuristics |= Acronym_NoVowels
+		doubt *= 0.2
+	}
+
+	// The shape of a word is only a clue if it was typed in uppercase, as otherwise it is more likely a foreign word or name, e.g. Kampf, Lloyd, Ohio
+	if upper && language == Language_English && len(word) > 2 {
+		l := len(word)
+		if (!isVowel(word[0]) && !isVowel(word[1]) && !inList(englishOnsets, string(word[0:2]))) || (!isVowel(word[l-2]) && !isVowel(word[l-1]) && !inList(englishCodas, string(word[l-2:]))) {
+			heuristics |= Acronym_Cluster
+			doubt *= 0.4
+		}
+	}
+
+	if upper && language == Language_English && len(word) <= 4 && inList(unusualEndings, string(word[len(word)-2:])) {
+		heuristics |= Acronym_VowelPair
+		doubt *= 0.5
+	}
+
+	if upper && len(word) <= 4 {
+		heuristics |= Acronym_Short
+		doubt *= 0.7
+	}
+
+	return heuristics, 1 - doubt
+}
@@ -0,0 +1,40 @@
+package titlecase
+
+import (
+ "testing"
+)
+
+func TestAcronyms(t *testing.T) {
+	f := New(Options{Language: Language_English, Acronyms: true})
+	tests := []struct {
+		in, want string
+	}{
+		{`HISTORY OF THE NAACP AND THE CIO`, `History of the NAACP and the CIO`},
+		{`THE RISE OF THE NSDAP`, `The Rise of the NSDAP`},
+		{`a history of the nkvd`, `A History of the NKVD`},
+		{`A report from the WHO on malaria`, `A Report From the WHO on Malaria`},
+		{`WAR AND PEACE`, `War and Peace`},
+		{`A DUO FOR PIANO AND VIOLIN`, `A Duo for Piano and Violin`},
+		{`THE CAT AND THE HAT`, `The Cat and the Hat`},
+		{`Mein Kampf`, `Mein Kampf`},
+		{`mein kampf`, `Mein Kampf`},
+		{`The Lloyd Family of Ohio`, `The Lloyd Family of Ohio`},
+		{`travels in asia and rio with dvorak`, `Travels in Asia and Rio With Dvorak`},
+		{`Letters of Mendelssohn and the Tau Cross`, `Letters of Mendelssohn and the Tau Cross`},
+		{`The History of the NAACP and the CIO`, `The History of the NAACP and the CIO`},
+	}
+	for _, test := range tests {
+		if got := f.Format(test.in); got != test.want {
+			t.Errorf("%q = %q, want %q", test.in, got, test.want)
+		}
+	}
+
+	_, acronyms := f.FormatAcronyms(`HISTORY OF THE NAACP AND THE CIO`)
+	if len(acronyms) != 2 || acronyms[0].Word != `NAACP` || acronyms[0].Heuristics & Acronym_Cluster == 0 || acronyms[1].Word != `CIO` || acronyms[1].Heuristics & Acronym_VowelPair == 0 {
+		t.Errorf("FormatAcronyms = %+v", acronyms)
+	}
+
+	if got, want := English(`HISTORY OF THE NAACP AND THE CIO`), `History of the Naacp and the Cio`; got != want {
+		t.Errorf("without Acronyms = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,1025 @@
+package titlecase
+
+import (
+ "strings"
+ "unicode"
+)
+
+// Parts of an author string other than the name, which are found before the rules are applied and removed before the name is split
+const (
+ partName		= 0
+ partDates		= 1
+ partRole		= 2
+ partDesignation	= 3
+ partFuller		= 4 // a fuller form of the given names, e.g. Tolkien, J. R. R. (John Ronald Reuel)
+ partNickname	= 5 // e.g. Herman "Babe" Ruth
+ partPseudonym	= 6 // pseud., pseudonym of, i.e., real name
+ partRealName	= 7 // the name that follows pseudonym of, i.e. or real name
+ partOthers		= 8 // the other names after a relator phrase, e.g. and Mary Jones in edited by John Smith and Mary Jones
+)
+
+// Whether the word ends a comma separated segment of an author string
+func endsSegment(ws *wordStruct) bool {
+	for _, r := range ws.puncAfter {
+		switch r {
+			case ',', ';', ')':
+				return true
+		}
+	}
+	return false
+}
+
+// Returns the index after the end of the segment beginning at from
+func segmentEnd(words []wordStruct, from int) int {
+	for i := from; i < len(words); i++ {
+		if i > from && len(words[i].puncBefore) > 0 && words[i].puncBefore[0] == '(' {
+			return i
+		}
+		if endsSegment(&words[i]) {
+			return i + 1
+		}
+	}
+	return len(words)
+}
+
+// Returns the number of words at the beginning of words that mark a pseudonym, e.g. 1 for pseud. and 2 for pseudonym of, or 0
+func pseudonymMarker(words []string) int {
+	if len(words) == 0 {
+		return 0
+	}
+	var next string
+	if len(words) > 1 {
+		next = strings.ToLower(strings.Trim(words[1], `.,;:()[]`))
+	}
+	switch strings.ToLower(strings.Trim(words[0], `.,;:()[]`)) {
+		case `i.e`, `d.i`:
+			return 1
+		case `d`:
+			if next == `i` { // d. i.
+				return 2
+			}
+		case `pseud`, `pseudonym`, `pseudonyme`, `pseudonimo`, `pseudónimo`:
+			switch next {
+				case `of`, `for`, `de`, `von`, `für`, `di`: return 2
+			}
+			return 1
+		case `real`:
+			if next == `name` {
+				return 2
+			}
+		case `vrai`:
+			if next == `nom` {
+				return 2
+			}
+		case `eigentlich`, `eigtl`:
+			return 1
+	}
+	return 0
+}
+
+// Returns the closing quote for an opening quote, or 0
+func closingQuote(r rune) rune {
+	switch r {
+		case '"', '\'': return r
+		case '“', '”': return '”'
+		case '‘': return '’'
+		case '«': return '»'
+		case '„': return '“'
+	}
+	return 0
+}
+
+// Words that can appear in life dates, other than numbers
+func isDateMarker(word string) bool {
+	switch word {
+		case `b`, `born`, `d`, `died`, `fl`, `flourished`, `active`, `ca`, `c`, `circa`, `approximately`, `approx`, `cent`, `century`, `or`, `bc`, `ad`, `bce`, `ce`, `b.c`, `a.d`,
+		 `b.c.e`, `c.e`, `geb`, `gest`, `né`, `née`, `mort`, `nato`, `morto`, `nacido`, `muerto`, `jh`, `jahrhundert`, `siècle`, `secolo`, `siglo`, `século`:
+			return true
+	}
+	return false
+}
+
+// Whether a word is a year, ordinal or other number found in dates: 1812, 8th, 1950s, 1812?
+func isDateNumber(word string) bool {
+	if len(word) == 0 || word[0] < '0' || word[0] > '9' {
+		return false
+	}
+	word = strings.TrimRight(word, `?`)
+	i := 0
+	for i < len(word) && word[i] >= '0' && word[i] <= '9' {
+		i++
+	}
+	switch word[i:] {
+		case ``, `s`, `st`, `nd`, `rd`, `th`, `e`, `er`, `o`, `a`:
+			return true
+	}
+	return false
+}
+
+// Whether the words are life dates: all are numbers or date markers, and there is at least one number
+func isDates(words []string) bool {
+	var number bool
+	for _, str := range words {
+		for _, word := range strings.Split(strings.ToLower(strings.Trim(str, `.,;:()[]`)), `-`) {
+			if isDateNumber(word) {
+				number = true
+			} else if len(word) > 0 && !isDateMarker(strings.TrimRight(word, `.`)) {
+				return false
+			}
+		}
+	}
+	return number
+}
+
+// Relator terms that follow a name, e.g. Smith, John, ed., and the role they stand for
+func relatorTerm(word string) string {
+	switch word {
+		case `ed`, `eds`, `editor`, `editors`, `hrsg`, `hg`, `hgg`, `herausgeber`, `éd`, `éds`, `dir`, `coord`, `cur`, `curatore`, `curatori`, `éditeur`, `editore`:
+			return `editor`
+		case `trans`, `transl`, `tr`, `translator`, `translators`, `übers`, `übersetzer`, `trad`, `traducteur`, `traductrice`, `traductor`, `traductora`, `traduttore`, `traduttrice`, `tradutor`, `tradutora`:
+			return `translator`
+		case `comp`, `comps`, `compiler`, `compilers`, `compilateur`, `compilatore`, `compilador`:
+			return `compiler`
+		case `illus`, `ill`, `illustr`, `illustrator`, `illustrators`, `illustrateur`, `illustratore`, `ilustrador`, `ilustradora`:
+			return `illustrator`
+	}
+	return ``
+}
+
+// Phrases that come before a name, e.g. translated by Jean Dupont, and the role they stand for
+func relatorPhrase(phrase string) string {
+	switch phrase {
+		case `edited by`, `ed by`, `eds by`, `hrsg von`, `hg von`, `herausgegeben von`, `a cura di`, `sous la direction de`, `édité par`, `editado por`, `edición de`, `edição de`, `organizado por`:
+			return `editor`
+		case `translated by`, `trans by`, `tr by`, `übersetzt von`, `übers von`, `traduit par`, `trad par`, `tradotto da`, `traduzione di`, `trad di`, `traducido por`, `traducción de`, `trad de`, `traduzido por`, `tradução de`:
+			return `translator`
+		case `compiled by`, `comp by`, `zusammengestellt von`, `compilé par`, `compilato da`, `compilado por`:
+			return `compiler`
+		case `illustrated by`, `illus by`, `illustriert von`, `illustré par`, `illustrato da`, `ilustrado por`, `ilustraciones de`, `ilustrações de`:
+			return `illustrator`
+	}
+	return ``
+}
+
+// Returns the number of words at the beginning of words that are a relator phrase and the role, or 0
+func leadingRole(words []string) (int, string) {
+	var phrase, role string
+	for i := 0; i < len(words) && i < 4; i++ {
+		if i > 0 {
+			phrase += ` `
+		}
+		phrase += strings.ToLower(strings.Trim(words[i], `.,;:()[]`))
+		if role = relatorPhrase(phrase); len(role) > 0 {
+			return i + 1, role
+		}
+	}
+	return 0, ``
+}
+
+// Returns the role if the words are a relator phrase or are all relator terms, e.g. ed. and trans.
+func relatorRole(words []string) string {
+	if n, role := leadingRole(words); n > 0 && n == len(words) {
+		return role
+	}
+	var role, term string
+	for _, str := range words {
+		str = strings.ToLower(strings.Trim(str, `.,;:()[]`))
+		switch str {
+			case ``: continue
+			case `and`, `&`, `et`, `und`, `y`, `e`:
+				if len(role) == 0 {
+					return ``
+				}
+				continue
+		}
+		if term = relatorTerm(str); len(term) == 0 {
+			return ``
+		}
+		if len(role) > 0 {
+			role += ` and `
+		}
+		role += term
+	}
+	return role
+}
+
+// Legal forms of companies, which only identify a corporate author at the end of the name, e.g. Acme S.A. but not Sa Majesté
+func isLegalForm(word string) bool {
+	switch word {
+		case `co`, `inc`, `ltd`, `llc`, `plc`, `corp`, `limited`, `incorporated`, `gmbh`, `ag`, `kg`, `s.a`, `s.l`, `s.p.a`, `s.r.l`, `sa`, `spa`, `srl`:
+			return true
+	}
+	return false
+}
+
+// Common given names, which show that a corporate word is a surname, e.g. John Press
+func isGivenName(word string) bool {
+	switch word {
+		case `john`, `james`, `william`, `george`, `charles`, `thomas`, `henry`, `robert`, `richard`, `edward`, `joseph`, `michael`, `peter`, `paul`, `mark`, `frank`, `frederick`,
+		 `arthur`, `albert`, `walter`, `harry`, `jack`, `samuel`, `matthew`, `andrew`, `stephen`, `mary`, `elizabeth`, `anne`, `ann`, `margaret`, `jane`, `sarah`, `susan`,
+		 `catherine`, `emma`, `alice`, `helen`, `ruth`, `joan`, `johann`, `hans`, `karl`, `friedrich`, `wilhelm`, `heinrich`, `jean`, `pierre`, `jacques`, `louis`, `marie`,
+		 `giovanni`, `giuseppe`, `maria`, `joão`:
+			return true
+	}
+	return isSpanishGivenName(word)
+}
+
+// Returns the content of each word, with a comma after it if it ends a segment, for isCorporate
+func contentsWithCommas(words []wordStruct) []string {
+	strs := contents(words)
+	for i := range words {
+		if strings.IndexRune(string(words[i].puncAfter), ',') > -1 {
+			strs[i] += `,`
+		}
+	}
+	return strs
+}
+
+// Whether the words are the name of a corporate author. A corporate word does not count if it is the surname of an inverted name, e.g. Bank, John,
+// or if all the other words are given names or initials, e.g. John Press.
+func (f *Formatter) isCorporate(words []string) bool {
+	last := len(words) - 1
+	for last > 0 && len(strings.Trim(words[last], `.,;:()[]`)) == 0 {
+		last--
+	}
+	var before, after, others int // corporate words before and after the first comma, and other words
+	var comma, ok bool
+	var first int // the number of words up to the first comma
+	given := true
+	var word string
+	for i, str := range words {
+		word = strings.ToLower(strings.Trim(str, `.,;:()[]`))
+		if len(word) == 0 {
+			continue
+		}
+		if _, ok = f.dict.corporate.Find([]rune(word)); ok && (i == last || !isLegalForm(word)) {
+			if comma {
+				after++
+			} else {
+				before++
+			}
+		} else {
+			others++
+			if !isInitial(str) && !isGivenName(word) {
+				given = false
+			}
+		}
+		if !comma && strings.HasSuffix(str, `,`) {
+			comma = true
+			first = i + 1
+		}
+	}
+	switch {
+		case before + after == 0:
+			return false
+		case first == 1 && before == 1 && after == 0 && first <= last: // Bank, John
+			return false
+		case others > 0 && given: // John Press
+			return false
+	}
+	return true
+}
+
+// Whether an author without a comma has the family name first, e.g. Mao Zedong, Bartók Béla
+func (f *Formatter) isFamilyFirst(words []wordStruct) bool {
+	if f.opt.NameOrder == NameOrder_Western {
+		return false
+	}
+	// The indexes of the first word of each name, skipping titles
+	names := make([]int, 0, 4)
+	var ok, joined bool
+	for i := range words {
+		ws := &words[i]
+		if ws.part != partName || len(ws.content) == 0 {
+			continue
+		}
+		if len(names) > 0 && strings.IndexRune(string(words[names[len(names)-1]].puncAfter), ',') > -1 {
+			return false // inverted with a comma
+		}
+		if !joined {
+			if _, ok = f.dict.titlesabv.Find(ws.content); ok && len(names) == 0 {
+				continue
+			}
+			if isInitial(string(ws.content)) {
+				return false
+			}
+			names = append(names, i)
+		}
+		joined = ws.spaceAfter > 1
+	}
+	if len(names) < 2 {
+		return false
+	}
+	if f.opt.NameOrder == NameOrder_Eastern {
+		return true
+	}
+	if len(names) > 3 {
+		return false
+	}
+	// A hyphenated first name is a given name, e.g. Lin-Manuel Miranda
+	if words[names[0]].spaceAfter == 2 {
+		return false
+	}
+	family := words[names[0]].content
+	if _, ok = f.dict.familyFirst.Find(family); ok {
+		// Wang Wei could be either way, so it is left in Western order, but Park Chan-wook is not
+		if len(names) == 2 && words[names[1]].spaceAfter != 2 {
+			if _, ok = f.dict.familyFirst.Find(words[names[1]].content); ok {
+				return false
+			}
+		}
+		return true
+	}
+	// Family names that are also Western names or words only count before a hyphenated given name, e.g. Kim Jong-un, or when written in capitals, e.g. ABE Shinzo
+	switch string(family) {
+		case `kim`, `lee`, `song`, `ma`, `he`, `lin`, `su`, `lu`, `du`, `ye`, `sun`, `pan`, `tan`, `abe`, `jung`, `han`, `moon`, `kiss`:
+			return words[names[1]].spaceAfter == 2 || isCapitalized(words[names[0]].original, words[names[1]].original)
+	}
+	return false
+}
+
+// Whether the family name was written in capitals and the given name was not, e.g. ABE Shinzo
+func isCapitalized(family, given []rune) bool {
+	if len(family) < 2 {
+		return false
+	}
+	for _, r := range family {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+	for _, r := range given {
+		if unicode.IsLower(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Arabic articles joined to the next word with a hyphen, e.g. al-Rahman, ad-Din. In titles only al and el, as the others are also English words.
+func isArticle(word []rune, author bool) bool {
+	switch string(word) {
+		case `al`, `el`:
+			return true
+		case `ad`, `ar`, `as`, `at`, `az`, `an`, `ash`, `ud`, `ul`, `ed`, `es`, `et`, `ez`, `en`:
+			return author
+	}
+	return false
+}
+
+// Arabic and Hebrew words for son or daughter of, which are lowercase after a given name and belong to the surname, e.g. Osama bin Laden
+func isPatronymic(word []rune) bool {
+	switch string(word) {
+		case `ibn`, `bin`, `bint`, `ben`, `bat`:
+			return true
+	}
+	return false
+}
+
+// Whether the word at i is a patronymic. Ben, bin, bat and bint are also given names, e.g. Ben Jonson, so they are only patronymics
+// if typed in lowercase or between two names, e.g. Moses Ben Maimon, and never at the beginning of the name
+func (f *Formatter) isPatronymicAt(words []wordStruct, i int) bool {
+	word := lowerKey(string(words[i].content))
+	if !isPatronymic(word) {
+		return false
+	}
+	if string(word) == `ibn` {
+		return true
+	}
+	before, after := -1, -1
+	for j := i - 1; j >= 0 && before < 0; j-- {
+		if words[j].part == partName && len(words[j].content) > 0 {
+			before = j
+		}
+	}
+	for j := i + 1; j < len(words) && after < 0; j++ {
+		if words[j].part == partName && len(words[j].content) > 0 {
+			after = j
+		}
+	}
+	if before < 0 {
+		return false
+	}
+	if len(words[i].original) > 0 && unicode.IsLower(words[i].original[0]) {
+		return true
+	}
+	if after < 0 {
+		return false
+	}
+	// The word before must be a name and not a title, e.g. Sir Ben Kingsley
+	prev := lowerKey(string(words[before].content))
+	if _, ok := f.dict.titles.Find(prev); ok || words[before].isTitle || words[before].isHonor {
+		return false
+	}
+	_, ok := f.dict.titlesabv.Find(prev)
+	return !ok
+}
+
+// Arabic words for father, mother or servant of, which are capitalized and belong with the next name, e.g. Abu Bakr, Abd al-Rahman
+func isKunya(word []rune) bool {
+	switch string(word) {
+		case `abu`, `abou`, `abi`, `umm`, `abd`, `abdul`, `abdel`:
+			return true
+	}
+	return false
+}
+
+// Peerage titles that can begin a territorial designation, e.g. Duke of Wellington
+func isPeerage(word string) bool {
+	switch strings.ToLower(strings.Trim(word, `.,;:()[]`)) {
+		case `duke`, `duchess`, `marquess`, `marquis`, `marchioness`, `earl`, `count`, `countess`, `viscount`, `viscountess`, `baron`, `baroness`, `lord`, `lady`, `prince`, `princess`,
+			`duc`, `duchesse`, `marquise`, `comte`, `comtesse`, `vicomte`, `vicomtesse`, `baronne`, `herzog`, `herzogin`, `graf`, `gräfin`, `freiherr`, `freifrau`, `fürst`, `fürstin`,
+			`duca`, `duchessa`, `marchese`, `marchesa`, `conte`, `contessa`, `principe`, `principessa`, `duque`, `duquesa`, `marqués`, `marquesa`, `conde`, `condesa`, `marquês`:
+			return true
+	}
+	return false
+}
+
+// Peerage titles that are used with the name of the peerage alone, e.g. Lord Byron
+func isPeerageName(title string) bool {
+	switch strings.ToLower(title) {
+		case `lord`, `baron`, `baroness`, `viscount`, `viscountess`, `marquess`, `marchioness`:
+			return true
+	}
+	return false
+}
+
+// Words that join a peerage title to the place, e.g. Duke of Wellington
+func isDesignationOf(word string) bool {
+	switch strings.ToLower(strings.Trim(word, `.,;:()[]`)) {
+		case `of`, `de`, `du`, `des`, `von`, `di`, `del`, `della`:
+			return true
+	}
+	return false
+}
+
+// Returns the index of the peerage title that begins a territorial designation, e.g. 2 in Arthur Wellesley Duke of Wellington, or -1
+func designationStart(words []string) int {
+	for i := 0; i < len(words) - 2; i++ {
+		if isPeerage(words[i]) && isDesignationOf(words[i+1]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Whether the words are a territorial designation, e.g. Duke of Wellington, or a peerage title that is completed by the surname, as in Byron, George Gordon Byron, Baron and Richelieu, Armand Jean du Plessis, duc de
+func isDesignation(words []string) bool {
+	if designationStart(words) == 0 {
+		return true
+	}
+	return (len(words) == 1 && isPeerage(words[0])) || (len(words) == 2 && isPeerage(words[0]) && isDesignationOf(words[1]))
+}
+
+// Completes a designation that is named after the surname, e.g. Baron Byron, or finds one in a name such as Lord Byron
+func completeDesignation(author *AuthorStruct) {
+	if len(author.Last) == 0 {
+		return
+	}
+	if len(author.Designation) == 0 {
+		if len(author.First) == 0 && len(author.Middle) == 0 && isPeerageName(author.Title) {
+			author.Designation = author.Title + ` ` + author.Last
+		}
+		return
+	}
+	words := strings.Fields(author.Designation)
+	if len(words) > 1 && !isDesignationOf(words[len(words)-1]) {
+		return
+	}
+	author.Designation += ` ` + author.Last
+	// George Gordon Byron, Baron repeats the surname at the end of the given names
+	if author.Middle == author.Last {
+		author.Middle = ``
+	} else {
+		author.Middle = strings.TrimSuffix(author.Middle, ` ` + author.Last)
+	}
+}
+
+// Returns the number of name words that are not in words[from:to], leaving out honors, abbreviated titles and generations
+func nameWordsOutside(words []wordStruct, from, to int) int {
+	var n int
+	for i := range words {
+		if (i >= from && i < to) || words[i].part != partName || len(words[i].content) == 0 || words[i].isHonor || words[i].isTitle || isGenerationWord(words[i].content) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// Returns the number of words beginning at i that are a title, trying the longest first, e.g. 3 for His Royal Highness, or 0
+// A title must be followed by the name, so King is not a title in King, Martin Luther, and by more than one word unless it is a peerage, so Earl is a given name in Earl Smith but Lord Byron has a title
+func (f *Formatter) titleWords(words []wordStruct, i int) int {
+	var phrase []rune
+	var ok bool
+	Outer:
+	for n := 3; n > 0; n-- {
+		if i + n >= len(words) || endsSegment(&words[i+n-1]) {
+			continue
+		}
+		if nameWordsOutside(words, i, i + n) < 2 && (n > 1 || !isPeerageName(string(words[i].content))) {
+			continue
+		}
+		phrase = phrase[0:0]
+		for j := i; j < i + n; j++ {
+			if len(words[j].content) == 0 || (j < i + n - 1 && words[j].spaceAfter != 1) {
+				continue Outer
+			}
+			if j > i {
+				phrase = append(phrase, ' ')
+			}
+			phrase = append(phrase, lowerKey(string(words[j].content))...)
+		}
+		if _, ok = f.dict.titles.Find(phrase); ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// Returns the index of the first given name of a name that is inverted with a comma, e.g. 1 in Smith, John, or 0 if it is not inverted
+// Segments that only hold honors, titles and generations are skipped, so Dr. John Smith, Ph.D. is not inverted
+func (f *Formatter) givenNamesStart(words []wordStruct) int {
+	var comma bool
+	for i := range words {
+		if words[i].part != partName || len(words[i].content) == 0 {
+			continue
+		}
+		if comma && !f.isQualifierSegment(words, i) {
+			return i
+		}
+		comma = strings.IndexRune(string(words[i].puncAfter), ',') > -1
+	}
+	return 0
+}
+
+// Whether the segment beginning at i only holds honors, titles and generations, e.g. Ph.D. or Jr. after a comma
+func (f *Formatter) isQualifierSegment(words []wordStruct, i int) bool {
+	var word []rune
+	var ok bool
+	for ; i < len(words); i++ {
+		ws := &words[i]
+		if ws.part == partName && len(ws.content) > 0 && !ws.isHonor && !ws.isTitle {
+			word = lowerKey(string(ws.content))
+			if _, ok = f.dict.titlesabv.Find(word); !ok && !isGenerationWord(word) {
+				return false
+			}
+		}
+		if endsSegment(ws) {
+			break
+		}
+	}
+	return true
+}
+
+// Whether the name is inverted with a comma, e.g. Smith, John
+func (f *Formatter) isInverted(words []wordStruct) bool {
+	return f.givenNamesStart(words) > 0
+}
+
+// Whether the word at i is a surname particle such as de, van or von, or und between von and zu
+func (f *Formatter) isParticle(words []wordStruct, i int) bool {
+	word := lowerKey(string(words[i].content))
+	if _, ok := f.dict.multilast.Find(word); ok {
+		return true
+	}
+	if i == 0 || i == len(words) - 1 {
+		return false
+	}
+	switch string(word) {
+		case `und`:
+			return string(lowerKey(string(words[i-1].content))) == `von` && string(lowerKey(string(words[i+1].content))) == `zu`
+		case `y`: // Ortega y Gasset
+			return f.opt.Language == Language_Spanish && len(words[i-1].content) > 1 && len(words[i+1].content) > 1
+		case `e`:
+			return f.opt.Language == Language_Portuguese && len(words[i-1].content) > 1 && len(words[i+1].content) > 1
+	}
+	return false
+}
+
+// Common Spanish given names that are not also common surnames, so that María Ángeles Pérez is not taken to have two surnames
+func isSpanishGivenName(word string) bool {
+	switch word {
+		case `josé`, `juan`, `luis`, `carlos`, `maría`, `ana`, `antonio`, `manuel`, `francisco`, `jesús`, `javier`, `miguel`, `ángel`, `rafael`, `pedro`, `pablo`, `fernando`, `jorge`,
+		 `alberto`, `sergio`, `alejandro`, `daniel`, `david`, `andrés`, `ramón`, `enrique`, `diego`, `joaquín`, `ignacio`, `eduardo`, `ricardo`, `roberto`, `mario`, `emilio`, `felipe`,
+		 `ángeles`, `carmen`, `isabel`, `dolores`, `pilar`, `teresa`, `mercedes`, `cristina`, `elena`, `laura`, `marta`, `lucía`, `paula`, `sara`, `beatriz`, `josefa`, `francisca`,
+		 `antonia`, `juana`, `inés`, `sofía`, `rocío`, `concepción`, `montserrat`, `guadalupe`, `victoria`, `alicia`, `margarita`:
+			return true
+	}
+	return false
+}
+
+// Common Portuguese given names, so that João Maria Neves is not taken to have two surnames
+func isPortugueseGivenName(word string) bool {
+	switch word {
+		case `joão`, `josé`, `antónio`, `antônio`, `francisco`, `manuel`, `pedro`, `paulo`, `carlos`, `luís`, `luiz`, `jorge`, `fernando`, `ricardo`, `rui`, `ruy`, `miguel`,
+		 `joaquim`, `sérgio`, `marcelo`, `eduardo`, `roberto`, `gilberto`, `mário`, `rafael`, `tiago`, `thiago`, `gustavo`, `vinícius`, `maria`, `ana`, `joana`, `luísa`,
+		 `teresa`, `helena`, `isabel`, `beatriz`, `fernanda`, `cecília`, `clarice`, `raquel`, `lúcia`, `sofia`, `marta`, `catarina`, `inês`, `margarida`, `adriana`:
+			return true
+	}
+	return false
+}
+
+// Whether the word is a common given name in the language of the formatter, for secondSurname
+func (f *Formatter) isCommonGivenName(word string) bool {
+	if f.opt.Language == Language_Portuguese {
+		return isPortugueseGivenName(word)
+	}
+	return isSpanishGivenName(word)
+}
+
+// Spanish and Portuguese names usually have two surnames, e.g. Gabriel García Márquez and João Cabral de Melo Neto, unless they are already joined with y or e or the name before is a given name.
+// Portuguese generational words such as Neto have already been moved to the suffix, so both give the final surname last. Returns the new start of the surname.
+func (f *Formatter) secondSurname(words []wordStruct, lastpos int) int {
+	for i := lastpos; i < len(words); i++ {
+		if (string(words[i].content) == `y` || string(words[i].content) == `e`) && f.isParticle(words, i) {
+			return lastpos
+		}
+	}
+	j := lastpos - 1
+	for j >= 0 && len(words[j].content) == 0 {
+		j--
+	}
+	if j < 0 || words[j].spaceAfter != 1 || isInitial(string(words[j].content)) || f.isParticle(words, j) || isKunya(lowerKey(string(words[j].content))) || f.isCommonGivenName(string(lowerKey(string(words[j].content)))) {
+		return lastpos
+	}
+	// Particles before the first surname go with it, e.g. Miguel de Cervantes Saavedra
+	for j > 0 && (len(words[j-1].content) == 0 || f.isParticle(words, j-1)) {
+		j--
+	}
+	// There must be a given name left
+	for i := 0; i < j; i++ {
+		if len(words[i].content) > 0 {
+			return j
+		}
+	}
+	return lastpos
+}
+
+// Abbreviated generational suffixes, e.g. Jr. Sr is not included as it is also a title (Señor, Sister), but it is a suffix at the end of a name.
+func isGenerationAbbr(word []rune) bool {
+	switch string(word) {
+		case `jr`, `jnr`, `snr`:
+			return true
+	}
+	return false
+}
+
+// Generational suffixes, e.g. Jr., Sr., Filho, Neto, Júnior
+func isGenerationWord(word []rune) bool {
+	switch string(word) {
+		case `jr`, `sr`, `jnr`, `snr`, `filho`, `filha`, `neto`, `neta`, `sobrinho`, `júnior`, `junior`, `hijo`:
+			return true
+	}
+	return false
+}
+
+// Whether the word at i is the only given name of an inverted name, e.g. Il in Kim, Il
+func (f *Formatter) isOnlyGivenName(words []wordStruct, i int) bool {
+	given := f.givenNamesStart(words)
+	if given == 0 || i < given {
+		return false
+	}
+	for j := given; j < len(words); j++ {
+		if j != i && words[j].part == partName && len(words[j].content) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Moves generational suffixes and Roman numerals at the end of the name, or of the surname of an inverted name, into Suffix, e.g. Henry Ford II, Ruy Castro Filho, Castro Filho, Ruy
+// Names that are only spelled like Roman numerals are not marked isRoman, so they stay in the name, e.g. Kim Jong Il
+func (f *Formatter) extractGenerations(words []wordStruct, author *AuthorStruct) {
+	end := len(words) - 1
+	for end >= 0 && len(words[end].content) == 0 {
+		end--
+	}
+	prev := -1
+	for i := 0; i <= end; i++ {
+		ws := &words[i]
+		if len(ws.content) == 0 {
+			continue
+		}
+		if prev > -1 && words[prev].spaceAfter == 1 && (i == end || endsSegment(ws)) && (isGenerationWord(lowerKey(string(ws.content))) || (ws.isRoman && len(ws.content) > 1)) {
+			author.Suffix = join(` `, author.Suffix, string(ws.content) + strings.TrimRight(string(ws.puncAfter), `,;)`))
+			if endsSegment(ws) { // keep the comma
+				words[prev].puncAfter = []rune(strings.TrimLeft(string(ws.puncAfter), `.`))
+			}
+			ws.content = ws.content[0:0]
+			continue
+		}
+		prev = i
+	}
+}
+
+// Capitalizes a surname particle according to the ParticleCase option
+func (f *Formatter) particleCase(words []wordStruct, i int) {
+	ws := &words[i]
+	switch f.opt.ParticleCase {
+		case ParticleCase_Keep:
+			if len(ws.original) > 0 && unicode.IsUpper(ws.original[0]) {
+				upperRune(ws.content, 0)
+			}
+			return
+		case ParticleCase_Upper:
+			if i == 0 || !f.isParticle(words, i - 1) {
+				upperRune(ws.content, 0)
+			}
+			return
+	}
+	// Capitalized only when it begins the name, e.g. Van Gogh, Vincent
+	for j := i - 1; j >= 0; j-- {
+		if len(words[j].content) > 0 && words[j].part == partName {
+			return
+		}
+	}
+	upperRune(ws.content, 0)
+}
+
+func contents(words []wordStruct) []string {
+	strs := make([]string, len(words))
+	for i := range words {
+		strs[i] = string(words[i].content)
+	}
+	return strs
+}
+
+// Returns where the other names start after a relator phrase that ends at n, e.g. edited by John Smith, Mary Jones and Bob Brown, or len(words) if it names one person
+func (f *Formatter) othersStart(words []wordStruct, n int) int {
+	c := n + 1
+	for ; c < len(words); c++ {
+		switch f.conjunction(strings.ToLower(string(words[c].content))) {
+			case sepAnd:
+			case sepWeakAnd: // y and e also join surnames, so must be followed by a full name
+				if len(words) - c < 3 {
+					continue
+				}
+			default:
+				continue
+		}
+		break
+	}
+	if c >= len(words) {
+		return len(words)
+	}
+	// Names in direct order separated by commas, but not John Smith, Jr., and Mary Jones
+	if end := segmentEnd(words, n); end < c && end - n > 1 && c - end > 1 {
+		return end
+	}
+	return c
+}
+
+// Marks the words that are not part of the name
+func (f *Formatter) markAuthorParts(words []wordStruct) {
+	n, _ := leadingRole(contents(words))
+	for i := 0; i < n; i++ {
+		words[i].part = partRole
+	}
+	if n > 0 {
+		var m int
+		for i := f.othersStart(words, n); i < len(words); i++ {
+			words[i].part = partOthers
+			// A relator phrase for the names after it, e.g. edited by John Smith; translated by Mary Jones
+			if endsSegment(&words[i-1]) {
+				if m, _ = leadingRole(contents(words[i:])); m > 0 {
+					for m += i; i < m; i++ {
+						words[i].part = partRole
+					}
+					i--
+				}
+			}
+		}
+	}
+	var end int
+	var segment []string
+	for i := n; i < len(words); i = end {
+		end = segmentEnd(words, i)
+		segment = contents(words[i:end])
+		if i == n { // the first segment is the name, but may end with a designation, e.g. The Duke of Wellington
+			if j := designationStart(segment); j > -1 {
+				for j += i; j < end; j++ {
+					words[j].part = partDesignation
+				}
+			}
+			continue
+		}
+		// Twain, Mark, pseud. and Twain, Mark, pseudonym of Samuel Langhorne Clemens, where the real name runs to the end unless it is in parentheses
+		if m := pseudonymMarker(segment); m > 0 {
+			for j := i; j < i + m; j++ {
+				words[j].part = partPseudonym
+			}
+			if i + m < end && (len(words[i].puncBefore) == 0 || words[i].puncBefore[0] != '(') {
+				end = len(words)
+			}
+			for j := i + m; j < end; j++ {
+				words[j].part = partRealName
+			}
+			if i + m < end {
+				words[i+m].isStart = true
+			}
+			continue
+		}
+		// Smith, Earl is a name, but Byron, George Gordon Byron, Baron is a designation
+		if isDesignation(segment) && (end - i > 1 || i != segmentEnd(words, n)) {
+			for j := i; j < end; j++ {
+				words[j].part = partDesignation
+			}
+			continue
+		}
+		if isDates(segment) {
+			for j := i; j < end; j++ {
+				words[j].part = partDates
+			}
+			continue
+		}
+		if len(relatorRole(segment)) == 0 {
+			continue
+		}
+		// Smith, Ed is a name, but Smith, John, ed and Smith, editor are roles
+		if i == segmentEnd(words, n) && end - i == 1 && len(words[i].content) <= 4 && len(words[i].puncBefore) == 0 && (len(words[i].puncAfter) == 0 || words[i].puncAfter[0] != '.') {
+			continue
+		}
+		for j := i; j < end; j++ {
+			words[j].part = partRole
+		}
+	}
+	// Any other part in parentheses is a fuller form of the given names if it expands their initials, and otherwise a nickname, e.g. Robert (Bob) Dylan
+	var part uint8
+	for i := n + 1; i < len(words); i = end {
+		end = segmentEnd(words, i)
+		if words[i].part != partName || len(words[i].puncBefore) == 0 || words[i].puncBefore[0] != '(' {
+			continue
+		}
+		part = partNickname
+		if expandsInitials(words, i, end) {
+			part = partFuller
+		}
+		for j := i; j < end; j++ {
+			words[j].part = part
+		}
+		words[i].isStart = true
+	}
+	// A nickname is in quotes
+	var q rune
+	for i := n; i < len(words); i++ {
+		if words[i].part != partName || len(words[i].puncBefore) == 0 {
+			continue
+		}
+		if q = closingQuote([]rune(string(words[i].puncBefore))[0]); q == 0 {
+			continue
+		}
+		for end = i; end < len(words) && !strings.ContainsRune(string(words[end].puncAfter), q); end++ {}
+		if end == len(words) {
+			continue
+		}
+		for j := i; j <= end; j++ {
+			words[j].part = partNickname
+		}
+		words[i].isStart = true
+		i = end
+	}
+}
+
+// Whether the words from and to, which are in parentheses, begin with the initials of the rest of the name in order, e.g. (John Ronald Reuel) after J. R. R.
+func expandsInitials(words []wordStruct, from, to int) bool {
+	var initials []rune
+	for i := range words {
+		if (i >= from && i < to) || words[i].part != partName || len(words[i].content) == 0 {
+			continue
+		}
+		if isInitial(string(words[i].content)) {
+			initials = append(initials, words[i].content[0])
+		}
+	}
+	if len(initials) == 0 {
+		return false
+	}
+	j := 0
+	for i := from; i < to && j < len(initials); i++ {
+		if len(words[i].content) > 0 && words[i].content[0] == initials[j] {
+			j++
+		}
+	}
+	return j == len(initials)
+}
+
+// Joins the words from and to into a string, as they are written in the formatted string
+func joinWords(words []wordStruct, from, to int) string {
+	var b strings.Builder
+	for i := from; i < to; i++ {
+		ws := &words[i]
+		b.WriteString(string(ws.puncBefore))
+		b.WriteString(string(ws.content))
+		b.WriteString(string(ws.puncAfter))
+		if i < to - 1 {
+			switch ws.spaceAfter {
+				case 1: b.WriteByte(' ')
+				case 2: b.WriteByte('-')
+				case 3: b.WriteByte('/')
+			}
+		} else if ws.spaceAfter == 2 { // open range, e.g. 1950-
+			b.WriteByte('-')
+		}
+	}
+	return strings.Trim(b.String(), ` ,;()`)
+}
+
+// Moves the parts marked by markAuthorParts from words into author, removing them from the words
+func (f *Formatter) extractAuthorParts(words []wordStruct, author *AuthorStruct) {
+	var i, j int
+	for i = 0; i < len(words); i++ {
+		if words[i].part == partName {
+			continue
+		}
+		for j = i; j < len(words) && words[j].part == words[i].part; j++ {}
+		switch words[i].part {
+			case partDates:
+				if len(author.Dates) == 0 {
+					author.Dates = joinWords(words, i, j)
+					author.Born, author.Died = parseDates(words[i:j])
+				}
+			case partRole:
+				if len(author.Role) == 0 {
+					author.Role = relatorRole(contents(words[i:j]))
+				}
+			case partDesignation:
+				if len(author.Designation) == 0 {
+					author.Designation = joinWords(words, i, j)
+				}
+			case partFuller:
+				if len(author.FullerForm) == 0 {
+					author.FullerForm = joinWords(words, i, j)
+				}
+			case partNickname:
+				if len(author.Nickname) == 0 {
+					author.Nickname = strings.Trim(joinWords(words, i, j), `"'“”‘’«»„`)
+				}
+			case partPseudonym:
+				author.IsPseudonym = true
+			case partRealName:
+				if author.RealName == nil {
+					_, author.RealName = f.format(strings.TrimLeft(joinWords(words, i, j), `: `), true)
+				}
+		}
+		for ; i < j; i++ {
+			words[i].content = words[i].content[0:0]
+		}
+		i--
+	}
+}
+
+// Finds the years of birth and death in life dates, e.g. 1812-1870, b. 1564, d. ca. 1450, 1950-
+func parseDates(words []wordStruct) (string, string) {
+	var born, died, qualifier string
+	var marker uint8 // 1 = born, 2 = died, 3 = flourished
+	var rangeStart bool // the previous number was followed by a hyphen
+	var last *string // the last value set, so that an era can be added to it
+	for i := range words {
+		ws := &words[i]
+		word := strings.ToLower(string(ws.content))
+		switch word {
+			case `b`, `born`, `geb`, `né`, `née`, `nato`, `nacido`:
+				marker = 1
+				continue
+			case `d`, `died`, `gest`, `mort`, `morto`, `muerto`:
+				marker = 2
+				continue
+			case `fl`, `flourished`, `active`, `cent`, `century`, `jh`, `jahrhundert`, `siècle`, `secolo`, `siglo`, `século`:
+				marker = 3
+				last = nil
+				continue
+			case `ca`, `c`, `circa`, `approximately`, `approx`:
+				qualifier = string(ws.content) + string(ws.puncAfter) + ` `
+				continue
+			case `bc`, `ad`, `bce`, `ce`, `b.c`, `a.d`, `b.c.e`, `c.e`:
+				if last != nil {
+					era := ` ` + string(ws.content) + strings.TrimRight(string(ws.puncAfter), `,;)`)
+					if last == &died && len(born) > 0 && born[len(born)-1] >= '0' && born[len(born)-1] <= '9' { // 427-347 B.C. are both B.C.
+						born += era
+					}
+					*last += era
+				}
+				continue
+		}
+		if !isDateNumber(word) || marker == 3 {
+			continue
+		}
+		value := qualifier + string(ws.content)
+		qualifier = ``
+		switch {
+			case marker == 1:
+				born = value
+				last = &born
+			case marker == 2 || rangeStart:
+				died = value
+				last = &died
+			case ws.spaceAfter == 2:
+				born = value
+				last = &born
+		}
+		marker = 0
+		rangeStart = ws.spaceAfter == 2
+	}
+	return born, died
+}
@@ -0,0 +1,262 @@
+package titlecase
+
+import (
+ "strings"
+ "testing"
+)
+
+func TestAuthorTitles(t *testing.T) {
+	var a *AuthorStruct
+	for _, word := range defaultDictionaries.titles.words {
+		title := string(word)
+		for _, in := range []string{title + ` John Smith`, strings.ToUpper(title) + ` JOHN SMITH`, `Smith, ` + title + ` John`} {
+			_, a = Author(in, Language_English)
+			if !strings.EqualFold(a.Title, title) || a.First != `John` || a.Last != `Smith` {
+				t.Errorf("%q: Title %q, First %q, Last %q", in, a.Title, a.First, a.Last)
+			}
+		}
+	}
+	tests := []struct {
+		in, title, first, last string
+	}{
+		{`sir walter scott`, `Sir`, `Walter`, `Scott`},
+		{`Rt. Hon. William Gladstone`, `Rt. Hon.`, `William`, `Gladstone`},
+		{`Her Majesty Queen Elizabeth Windsor`, `Her Majesty Queen`, `Elizabeth`, `Windsor`},
+		{`Sister Mary Joseph Smith`, `Sister Mary`, `Joseph`, `Smith`},
+		{`Scott, Sir Walter`, `Sir`, `Walter`, `Scott`},
+		{`Lord Byron`, `Lord`, ``, `Byron`},
+		{`San Martin, Jose`, ``, `Jose`, `San Martin`},
+		{`King, Martin Luther`, ``, `Martin`, `King`},
+		{`Earl Smith`, ``, `Earl`, `Smith`},
+		{`Dr. Smith`, `Dr.`, ``, `Smith`},
+		{`Dr. John Smith, Ph.D.`, `Dr.`, `John`, `Smith`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Dr.`, `John`, `Smith`},
+		{`Smith, Jr., John`, ``, `John`, `Smith`},
+	}
+	for _, test := range tests {
+		_, a = Author(test.in, Language_English)
+		if a.Title != test.title || a.First != test.first || a.Last != test.last {
+			t.Errorf("%q: Title %q, First %q, Last %q, want %q, %q, %q", test.in, a.Title, a.First, a.Last, test.title, test.first, test.last)
+		}
+	}
+}
+
+func TestAuthorSurnames(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in, first, middle, last, suffix string
+	}{
+		{Language_Portuguese, `João Cabral de Melo Neto`, `João`, ``, `Cabral de Melo`, `Neto`},
+		{Language_Portuguese, `Carlos Drummond de Andrade`, `Carlos`, ``, `Drummond de Andrade`, ``},
+		{Language_Portuguese, `Joaquim Maria Machado de Assis`, `Joaquim`, `Maria`, `Machado de Assis`, ``},
+		{Language_Portuguese, `Ruy Barbosa de Oliveira Filho`, `Ruy`, ``, `Barbosa de Oliveira`, `Filho`},
+		{Language_Portuguese, `Paulo Coelho Júnior`, `Paulo`, ``, `Coelho`, `Júnior`},
+		{Language_Portuguese, `João Maria Neves`, `João`, `Maria`, `Neves`, ``},
+		{Language_Portuguese, `Jorge Amado`, `Jorge`, ``, `Amado`, ``},
+		{Language_Spanish, `Gabriel García Márquez`, `Gabriel`, ``, `García Márquez`, ``},
+		{Language_Spanish, `José Ortega y Gasset`, `José`, ``, `Ortega y Gasset`, ``},
+	}
+	var a *AuthorStruct
+	for _, test := range tests {
+		_, a = Author(test.in, test.language)
+		if a.First != test.first || a.Middle != test.middle || a.Last != test.last || a.Suffix != test.suffix {
+			t.Errorf("%q: First %q, Middle %q, Last %q, Suffix %q, want %q, %q, %q, %q", test.in, a.First, a.Middle, a.Last, a.Suffix, test.first, test.middle, test.last, test.suffix)
+		}
+	}
+	// The final surname is matched whichever way the name is written
+	_, a = Author(`João Cabral de Melo Neto`, Language_Portuguese)
+	_, b := Author(`Melo Neto, João Cabral de`, Language_Portuguese)
+	if a.MatchKey() != b.MatchKey() || CompareAuthors(*a, *b) == 0 {
+		t.Errorf("%q and %q do not match: %q, %q", a.Last, b.Last, a.MatchKey(), b.MatchKey())
+	}
+}
+
+func TestAuthorRomanSuffixes(t *testing.T) {
+	tests := []struct {
+		order uint8
+		in, first, middle, last, suffix string
+	}{
+		{NameOrder_Auto, `Kim Jong Il`, `Kim`, `Jong`, `Il`, ``},
+		{NameOrder_Eastern, `Kim Jong Il`, `Jong`, `Il`, `Kim`, ``},
+		{NameOrder_Eastern, `Kim Jong Xi`, `Jong`, `Xi`, `Kim`, ``},
+		{NameOrder_Auto, `Zhang Wei Xi`, `Wei`, `Xi`, `Zhang`, ``},
+		{NameOrder_Auto, `Zhang, Xi`, `Xi`, ``, `Zhang`, ``},
+		{NameOrder_Auto, `Henry Ford II`, `Henry`, ``, `Ford`, `II`},
+		{NameOrder_Auto, `Ford, Henry, II`, `Henry`, ``, `Ford`, `II`},
+		{NameOrder_Auto, `Ford II, Henry`, `Henry`, ``, `Ford`, `II`},
+	}
+	var a *AuthorStruct
+	for _, test := range tests {
+		_, a = New(Options{Language: Language_English, Author: true, NameOrder: test.order}).Author(test.in)
+		if a.First != test.first || a.Middle != test.middle || a.Last != test.last || a.Suffix != test.suffix {
+			t.Errorf("%q: First %q, Middle %q, Last %q, Suffix %q, want %q, %q, %q, %q", test.in, a.First, a.Middle, a.Last, a.Suffix, test.first, test.middle, test.last, test.suffix)
+		}
+	}
+}
+
+func TestAuthorHonorsAfterComma(t *testing.T) {
+	tests := []struct {
+		in, suffix, display string
+	}{
+		{`Dr. John Smith, Ph.D.`, `Ph.D.`, `Dr. John Smith Ph.D.`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Jr. Ph.D.`, `Dr. John A. Smith Jr. Ph.D.`},
+		{`Smith, John, Ph.D.`, `Ph.D.`, `John Smith Ph.D.`},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, Language_English)
+		if a.Suffix != test.suffix || a.Display() != test.display {
+			t.Errorf("%q: Suffix %q, Display %q, want %q, %q", test.in, a.Suffix, a.Display(), test.suffix, test.display)
+		}
+	}
+}
+
+func TestAuthorPatronymics(t *testing.T) {
+	tests := []struct {
+		in, out, first, last string
+	}{
+		{`Ben Jonson`, `Ben Jonson`, `Ben`, `Jonson`},
+		{`BEN AFFLECK`, `Ben Affleck`, `Ben`, `Affleck`},
+		{`Bat Masterson`, `Bat Masterson`, `Bat`, `Masterson`},
+		{`Sir Ben Kingsley`, `Sir Ben Kingsley`, `Ben`, `Kingsley`},
+		{`Ahmad ibn Hanbal`, `Ahmad ibn Hanbal`, `Ahmad`, `ibn Hanbal`},
+		{`Moses ben Maimon`, `Moses ben Maimon`, `Moses`, `ben Maimon`},
+		{`MOSES BEN MAIMON`, `Moses ben Maimon`, `Moses`, `ben Maimon`},
+		{`Osama bin Laden`, `Osama bin Laden`, `Osama`, `bin Laden`},
+	}
+	for _, test := range tests {
+		out, a := Author(test.in, Language_English)
+		if out != test.out || a.First != test.first || a.Last != test.last {
+			t.Errorf("%q = %q, First %q, Last %q, want %q, %q, %q", test.in, out, a.First, a.Last, test.out, test.first, test.last)
+		}
+	}
+}
+
+func TestAuthorNameOrder(t *testing.T) {
+	tests := []struct {
+		in, first, last string
+		familyFirst bool
+	}{
+		{`Mao Zedong`, `Zedong`, `Mao`, true},
+		{`Murakami Haruki`, `Haruki`, `Murakami`, true},
+		{`Park Chan-wook`, `Chan-Wook`, `Park`, true},
+		{`Kim Jong-un`, `Jong-Un`, `Kim`, true},
+		{`Moon Jae-in`, `Jae-In`, `Moon`, true},
+		{`ABE Shinzo`, `Shinzo`, `Abe`, true},
+		{`Abe Lincoln`, `Abe`, `Lincoln`, false},
+		{`Han Solo`, `Han`, `Solo`, false},
+		{`Lin-Manuel Miranda`, `Lin-Manuel`, `Miranda`, false},
+		{`Ma Rainey`, `Ma`, `Rainey`, false},
+		{`Moon Unit Zappa`, `Moon`, `Zappa`, false},
+		{`Wang Wei`, `Wang`, `Wei`, false},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, Language_English)
+		if a.First != test.first || a.Last != test.last || a.FamilyFirst != test.familyFirst {
+			t.Errorf("%q: First %q, Last %q, FamilyFirst %v, want %q, %q, %v", test.in, a.First, a.Last, a.FamilyFirst, test.first, test.last, test.familyFirst)
+		}
+	}
+}
+
+func TestAuthorFullerForms(t *testing.T) {
+	tests := []struct {
+		in, first, middle, fuller, nickname string
+	}{
+		{`Tolkien, J. R. R. (John Ronald Reuel)`, `J.`, `R. R.`, `John Ronald Reuel`, ``},
+		{`J.R.R. (John Ronald Reuel) Tolkien`, `J.`, `R. R.`, `John Ronald Reuel`, ``},
+		{`Lewis, C. S. (Clive Staples), 1898-1963`, `C.`, `S.`, `Clive Staples`, ``},
+		{`Smith, John R. (John Robert)`, `John`, `R.`, `John Robert`, ``},
+		{`Robert (Bob) Dylan`, `Robert`, ``, ``, `Bob`},
+		{`Dylan, Bob (Robert)`, `Bob`, ``, ``, `Robert`},
+		{`Herman "Babe" Ruth`, `Herman`, ``, ``, `Babe`},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, Language_English)
+		if a.First != test.first || a.Middle != test.middle || a.FullerForm != test.fuller || a.Nickname != test.nickname {
+			t.Errorf("%q: First %q, Middle %q, FullerForm %q, Nickname %q, want %q, %q, %q, %q", test.in, a.First, a.Middle, a.FullerForm, a.Nickname, test.first, test.middle, test.fuller, test.nickname)
+		}
+	}
+}
+
+func TestAuthorRoles(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in, out, role string
+	}{
+		{Language_German, `hrsg. von Hans Müller`, `Hrsg. von Hans Müller`, `editor`},
+		{Language_English, `edited by john smith`, `Edited by John Smith`, `editor`},
+		{Language_English, `translated by Jean Dupont`, `Translated by Jean Dupont`, `translator`},
+		{Language_Italian, `a cura di mario rossi`, `A cura di Mario Rossi`, `editor`},
+		{Language_English, `Smith, John, ed.`, `Smith, John, ed`, `editor`},
+		{Language_German, `Müller, Hans, Hrsg.`, `Müller, Hans, hrsg`, `editor`},
+	}
+	for _, test := range tests {
+		out, a := Author(test.in, test.language)
+		if out != test.out || a.Role != test.role {
+			t.Errorf("%q = %q, Role %q, want %q, %q", test.in, out, a.Role, test.out, test.role)
+		}
+	}
+}
+
+func TestAuthorDates(t *testing.T) {
+	tests := []struct {
+		in, first, last, dates, born, died string
+	}{
+		{`Dickens, Charles, 1812-1870`, `Charles`, `Dickens`, `1812-1870`, `1812`, `1870`},
+		{`Dickens, Charles (1812-1870)`, `Charles`, `Dickens`, `1812-1870`, `1812`, `1870`},
+		{`Shakespeare, William, b. 1564`, `William`, `Shakespeare`, `b. 1564`, `1564`, ``},
+		{`Chaucer, Geoffrey, d. 1400`, `Geoffrey`, `Chaucer`, `d. 1400`, ``, `1400`},
+		{`Dante Alighieri, ca. 1265-1321`, `Dante`, `Alighieri`, `ca. 1265-1321`, `ca. 1265`, `1321`},
+		{`Smith, John, 1950-`, `John`, `Smith`, `1950-`, `1950`, ``},
+		{`Homer, fl. 8th cent. B.C.`, ``, `Homer`, `fl. 8th cent. B.C`, ``, ``},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, Language_English)
+		if a.First != test.first || a.Last != test.last || a.Dates != test.dates || a.Born != test.born || a.Died != test.died {
+			t.Errorf("%q: First %q, Last %q, Dates %q, Born %q, Died %q, want %q, %q, %q, %q, %q", test.in, a.First, a.Last, a.Dates, a.Born, a.Died, test.first, test.last, test.dates, test.born, test.died)
+		}
+	}
+}
+
+func TestAuthorParticles(t *testing.T) {
+	tests := []struct {
+		language, particleCase uint8
+		in, out, first, last string
+	}{
+		{Language_English, ParticleCase_Lower, `Ludwig Mies van der Rohe`, `Ludwig Mies van der Rohe`, `Ludwig`, `van der Rohe`},
+		{Language_English, ParticleCase_Lower, `Charles de la Fontaine`, `Charles de la Fontaine`, `Charles`, `de la Fontaine`},
+		{Language_French, ParticleCase_Lower, `jean de la fontaine`, `Jean de la Fontaine`, `Jean`, `de la Fontaine`},
+		{Language_German, ParticleCase_Lower, `Johann von und zu Liechtenstein`, `Johann von und zu Liechtenstein`, `Johann`, `von und zu Liechtenstein`},
+		{Language_Generic, ParticleCase_Lower, `Rohe, Ludwig van der`, `Rohe, Ludwig van der`, `Ludwig`, `van der Rohe`},
+		{Language_English, ParticleCase_Lower, `van der Rohe, Ludwig Mies`, `Van der Rohe, Ludwig Mies`, `Ludwig`, `Van der Rohe`},
+		{Language_English, ParticleCase_Lower, `DE LA FONTAINE, JEAN`, `De la Fontaine, Jean`, `Jean`, `De la Fontaine`},
+		{Language_Generic, ParticleCase_Upper, `Herman van rompuy`, `Herman Van Rompuy`, `Herman`, `Van Rompuy`},
+		{Language_Generic, ParticleCase_Upper, `Jan Van Den Berg`, `Jan Van den Berg`, `Jan`, `Van den Berg`},
+		{Language_Generic, ParticleCase_Keep, `Jan Van Den Berg`, `Jan Van Den Berg`, `Jan`, `Van Den Berg`},
+		{Language_Generic, ParticleCase_Keep, `Herman van rompuy`, `Herman van Rompuy`, `Herman`, `van Rompuy`},
+	}
+	for _, test := range tests {
+		out, a := New(Options{Language: test.language, Author: true, ParticleCase: test.particleCase}).Author(test.in)
+		if out != test.out || a.First != test.first || a.Last != test.last {
+			t.Errorf("%q (particle case %d) = %q, First %q, Last %q, want %q, %q, %q", test.in, test.particleCase, out, a.First, a.Last, test.out, test.first, test.last)
+		}
+	}
+}
+
+func TestAuthorDesignations(t *testing.T) {
+	tests := []struct {
+		in, first, last, title, designation, dates string
+	}{
+		{`Wellesley, Arthur, Duke of Wellington, 1769-1852`, `Arthur`, `Wellesley`, ``, `Duke of Wellington`, `1769-1852`},
+		{`Arthur Wellesley, Duke of Wellington`, `Arthur`, `Wellesley`, ``, `Duke of Wellington`, ``},
+		{`The Duke of Wellington`, ``, ``, ``, `Duke of Wellington`, ``},
+		{`Lord Byron`, ``, `Byron`, `Lord`, `Lord Byron`, ``},
+		{`Byron, George Gordon Byron, Baron, 1788-1824`, `George`, `Byron`, ``, `Baron Byron`, `1788-1824`},
+		{`Smith, Earl`, `Earl`, `Smith`, ``, ``, ``},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, Language_English)
+		if a.First != test.first || a.Last != test.last || a.Title != test.title || a.Designation != test.designation || a.Dates != test.dates {
+			t.Errorf("%q: First %q, Last %q, Title %q, Designation %q, Dates %q, want %q, %q, %q, %q, %q", test.in, a.First, a.Last, a.Title, a.Designation, a.Dates, test.first, test.last, test.title, test.designation, test.dates)
+		}
+	}
+}
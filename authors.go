@@ -0,0 +1,279 @@
+package titlecase
+
+import (
+ "html"
+ "strings"
+ "unicode"
+)
+
+// Separators between the parts of an author list
+const (
+ sepEnd			= 0
+ sepComma		= 1
+ sepSemicolon	= 2
+ sepAnd			= 3 // and, &, et, und, Italian e
+ sepWeakAnd		= 4 // y, e, which also join Spanish and Portuguese surnames
+)
+
+type authorPart struct {
+ words []string
+ sep uint8
+ conj string // the conjunction, if sep is sepAnd or sepWeakAnd
+}
+
+// Authors splits and formats a list of authors using the default formatter for the language.
+func Authors(str string, language uint8) ([]AuthorStruct, bool) {
+	return newFormatter(language).Authors(str)
+}
+
+// Authors splits a list of authors and formats each one. The bool is true if the list was truncated with "et al." or similar.
+func (f *Formatter) Authors(str string) ([]AuthorStruct, bool) {
+	parts, truncated := f.splitAuthors(html.UnescapeString(str))
+	authors := make([]AuthorStruct, 0, len(parts))
+	var author *AuthorStruct
+	var role string
+	for _, part := range f.groupAuthors(parts) {
+		if _, author = f.format(part, true); author != nil {
+			// A relator phrase applies to every name after it, e.g. edited by John Smith and Mary Jones
+			if n, r := leadingRole(strings.Fields(part)); n > 0 {
+				role = r
+			} else if len(author.Role) == 0 {
+				author.Role = role
+			}
+			authors = append(authors, *author)
+		}
+	}
+	return authors, truncated
+}
+
+func (f *Formatter) conjunction(word string) uint8 {
+	switch word {
+		case `and`, `&`: return sepAnd
+	}
+	switch f.opt.Language {
+		case Language_Generic, Language_French:
+			if word == `et` {
+				return sepAnd
+			}
+			if f.opt.Language == Language_Generic && word == `und` {
+				return sepAnd
+			}
+		case Language_German:
+			if word == `und` {
+				return sepAnd
+			}
+		case Language_Italian:
+			switch word {
+				case `e`, `ed`: return sepAnd
+			}
+		case Language_Spanish:
+			switch word {
+				case `y`, `e`: return sepWeakAnd
+			}
+		case Language_Portuguese:
+			if word == `e` {
+				return sepWeakAnd
+			}
+	}
+	return sepEnd
+}
+
+// Returns the number of words that truncate the author list (et al., and others, u. a., etc.) at the beginning of words, or 0
+func (f *Formatter) truncation(words []string) int {
+	if len(words) == 0 {
+		return 0
+	}
+	w1 := strings.ToLower(strings.Trim(words[0], `.,;:()[]`))
+	var w2 string
+	if len(words) > 1 {
+		w2 = strings.ToLower(strings.Trim(words[1], `.,;:()[]`))
+	}
+	switch w1 {
+		case `u.a`: return 1
+		case `et`:
+			switch w2 {
+				case `al`, `alii`, `alia`, `autres`: return 2
+			}
+		case `and`, `y`, `e`:
+			switch w2 {
+				case `others`, `otros`, `outros`, `altri`: return 2
+			}
+		case `u`:
+			if w2 == `a` && f.opt.Language == Language_German { // otherwise it could be initials
+				return 2
+			}
+	}
+	return 0
+}
+
+func (f *Formatter) splitAuthors(str string) ([]authorPart, bool) {
+	words := strings.Fields(str)
+	parts := make([]authorPart, 0, 4)
+	var part authorPart
+	var truncated bool
+	var sep uint8
+	var word, lower string
+	end := func(sep uint8, conj string) {
+		if len(part.words) > 0 {
+			part.sep = sep
+			part.conj = conj
+			parts = append(parts, part)
+		} else if len(parts) > 0 && sep > parts[len(parts)-1].sep { // e.g. a comma before "and"
+			parts[len(parts)-1].sep = sep
+			parts[len(parts)-1].conj = conj
+		}
+		part = authorPart{}
+	}
+	for i := 0; i < len(words); i++ {
+		if f.truncation(words[i:]) > 0 {
+			truncated = true
+			break
+		}
+		word = words[i]
+		lower = strings.ToLower(word)
+		if sep = f.conjunction(lower); sep != sepEnd {
+			end(sep, lower)
+			continue
+		}
+		switch word[len(word)-1] {
+			case ',':
+				word = word[0:len(word)-1]
+				sep = sepComma
+			case ';':
+				word = word[0:len(word)-1]
+				sep = sepSemicolon
+		}
+		if len(word) > 0 {
+			part.words = append(part.words, word)
+		}
+		if sep != sepEnd {
+			end(sep, ``)
+		}
+	}
+	end(sepEnd, ``)
+	if len(parts) > 0 {
+		parts[len(parts)-1].sep = sepEnd
+	}
+
+	// y and e join two surnames unless they are followed by a full name, or come after the given names of an inverted name
+	for i := 0; i < len(parts) - 1; i++ {
+		if parts[i].sep != sepWeakAnd || len(parts[i+1].words) > 1 || (i > 0 && parts[i-1].sep == sepComma) {
+			continue
+		}
+		parts[i].words = append(append(parts[i].words, parts[i].conj), parts[i+1].words...)
+		parts[i].sep = parts[i+1].sep
+		parts[i].conj = parts[i+1].conj
+		parts = append(parts[0:i+1], parts[i+2:]...)
+		i--
+	}
+	
+	// and joins two words of a corporate name, e.g. Procter & Gamble Company
+	for i := 0; i < len(parts) - 1; i++ {
+		if parts[i].sep != sepAnd || len(parts[i].words) > 1 || (i > 0 && parts[i-1].sep == sepComma) || !f.isCorporate(parts[i+1].words) {
+			continue
+		}
+		parts[i].words = append(append(parts[i].words, parts[i].conj), parts[i+1].words...)
+		parts[i].sep = parts[i+1].sep
+		parts[i].conj = parts[i+1].conj
+		parts = append(parts[0:i+1], parts[i+2:]...)
+	}
+	return parts, truncated
+}
+
+func isInitial(word string) bool {
+	word = strings.TrimRight(word, `.`)
+	if strings.IndexByte(word, '.') > -1 { // J.R.R
+		return true
+	}
+	n := 0
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+		n++
+	}
+	return n == 1
+}
+
+func hasInitial(words []string) bool {
+	for _, word := range words {
+		if isInitial(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the words look like the given names that follow an inverted surname
+func isGivenNames(words []string) bool {
+	if len(words) == 1 {
+		return true
+	}
+	for _, word := range words {
+		if !isInitial(word) {
+			return isInitial(words[len(words)-1])
+		}
+	}
+	return true
+}
+
+// Whether a part following a comma belongs to the author before it, such as a suffix or honor
+func (f *Formatter) isAttachment(words []string) bool {
+	if isDates(words) || len(relatorRole(words)) > 0 || isDesignation(words) || pseudonymMarker(words) > 0 {
+		return true
+	}
+	if len(words) == 1 && f.isCorporate(words) { // Acme Publishing Co., Ltd.
+		return true
+	}
+	var ok bool
+	var word []rune
+	for _, str := range words {
+		word = lowerKey(strings.Trim(str, `.,;:()[]`))
+		if len(word) == 0 {
+			continue
+		}
+		if _, ok = f.dict.honor.Find(word); ok {
+			continue
+		}
+		if _, ok = f.dict.titlesabv.Find(word); ok {
+			continue
+		}
+		if len(word) > 1 && f.dict.isRoman(word) {
+			continue
+		}
+		if isGenerationWord(word) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// Groups the parts into one string for each author
+func (f *Formatter) groupAuthors(parts []authorPart) []string {
+	authors := make([]string, 0, len(parts))
+	var str string
+	var p *authorPart
+	for i := 0; i < len(parts); i++ {
+		p = &parts[i]
+		str = strings.Join(p.words, ` `)
+		// Inverted name, e.g. Smith, John
+		if p.sep == sepComma && i < len(parts) - 1 && !f.isAttachment(parts[i+1].words) {
+			if len(p.words) == 1 || (!hasInitial(p.words) && isGivenNames(parts[i+1].words)) {
+				i++
+				str += `, ` + strings.Join(parts[i].words, ` `)
+			}
+		}
+		for i < len(parts) - 1 && parts[i].sep == sepComma && f.isAttachment(parts[i+1].words) {
+			i++
+			str += `, ` + strings.Join(parts[i].words, ` `)
+			// The real name of a pseudonym may be inverted, e.g. Eliot, George, i.e. Evans, Mary Ann
+			if m := pseudonymMarker(parts[i].words); m > 0 && len(parts[i].words) == m + 1 && parts[i].sep == sepComma && i < len(parts) - 1 && !f.isAttachment(parts[i+1].words) {
+				i++
+				str += `, ` + strings.Join(parts[i].words, ` `)
+			}
+		}
+		authors = append(authors, str)
+	}
+	return authors
+}
@@ -0,0 +1,108 @@
+package titlecase
+
+import (
+ "testing"
+)
+
+func TestPseudonymDoesNotChangeHonors(t *testing.T) {
+	if _, a := Author(`Eliot, George, i.e. Evans, Mary Ann`, Language_English); !a.IsPseudonym || a.RealName == nil || a.RealName.Last != `Evans` {
+		t.Fatalf("pseudonym not parsed: %+v", *a)
+	}
+	if got, want := English(`he said i.e. nothing`), `He Said I.e. Nothing`; got != want {
+		t.Errorf("English after a pseudonym = %q, want %q", got, want)
+	}
+}
+
+func TestCorporate(t *testing.T) {
+	tests := []struct {
+		in, corporate string
+	}{
+		{`Oxford University Press`, `Oxford University Press`},
+		{`Acme Co., Ltd.`, `Acme Co., Ltd`},
+		{`Microsoft, Inc.`, `Microsoft, Inc`},
+		{`Bank of England`, `Bank of England`},
+		{`Banco de España`, `Banco de España`},
+		{`Procter & Gamble Company`, `Procter & Gamble Company`},
+		{`United States. Dept. of Agriculture`, `United States. Dept. of Agriculture`},
+		{`Bank, John`, ``},
+		{`Press, Frank`, ``},
+		{`John Press`, ``},
+		{`J. Press`, ``},
+		{`Co, Joseph`, ``},
+		{`Sa Majesté John Smith`, ``},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Corporate != test.corporate {
+			t.Errorf("%q: Corporate %q, want %q", test.in, a.Corporate, test.corporate)
+		}
+	}
+	authors, _ := Authors(`Procter & Gamble Company and Smith, John`, Language_English)
+	if len(authors) != 2 || authors[0].Corporate != `Procter & Gamble Company` || authors[1].Last != `Smith` {
+		t.Errorf("Authors = %+v", authors)
+	}
+}
+
+func TestConjunctions(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in string
+		last []string
+	}{
+		{Language_Italian, `Rossi e Bianchi`, []string{`Rossi`, `Bianchi`}},
+		{Language_Italian, `Mario Rossi ed Anna Bianchi`, []string{`Rossi`, `Bianchi`}},
+		{Language_English, `Smith and Jones`, []string{`Smith`, `Jones`}},
+		{Language_German, `Müller und Schmidt`, []string{`Müller`, `Schmidt`}},
+	}
+	for _, test := range tests {
+		authors, _ := Authors(test.in, test.language)
+		if len(authors) != len(test.last) {
+			t.Errorf("%q: %d authors, want %d", test.in, len(authors), len(test.last))
+			continue
+		}
+		for i, a := range authors {
+			if a.Last != test.last[i] {
+				t.Errorf("%q: author %d Last %q, want %q", test.in, i, a.Last, test.last[i])
+			}
+		}
+	}
+}
+
+func TestLeadingRoles(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in string
+		last, role []string
+	}{
+		{Language_English, `edited by John Smith and Mary Jones`, []string{`Smith`, `Jones`}, []string{`editor`, `editor`}},
+		{Language_English, `edited by John Smith, Mary Jones and Bob Brown`, []string{`Smith`, `Jones`, `Brown`}, []string{`editor`, `editor`, `editor`}},
+		{Language_English, `edited by John Smith and Mary Jones; translated by Bob Brown`, []string{`Smith`, `Jones`, `Brown`}, []string{`editor`, `editor`, `translator`}},
+		{Language_German, `hrsg. von Hans Müller und Eva Schmidt`, []string{`Müller`, `Schmidt`}, []string{`editor`, `editor`}},
+		{Language_English, `John Smith and Mary Jones`, []string{`Smith`, `Jones`}, []string{``, ``}},
+	}
+	for _, test := range tests {
+		authors, _ := Authors(test.in, test.language)
+		if len(authors) != len(test.last) {
+			t.Errorf("%q: %d authors, want %d", test.in, len(authors), len(test.last))
+			continue
+		}
+		for i, a := range authors {
+			if a.Last != test.last[i] || a.Role != test.role[i] {
+				t.Errorf("%q: author %d Last %q, Role %q, want %q, %q", test.in, i, a.Last, a.Role, test.last[i], test.role[i])
+			}
+		}
+	}
+	// Author reads the first name after the relator phrase
+	authorTests := []struct {
+		in, out, first, last string
+	}{
+		{`edited by john smith and mary jones`, `Edited by John Smith and Mary Jones`, `John`, `Smith`},
+		{`edited by John Smith, Jr., and Mary Jones`, `Edited by John Smith, Jr., and Mary Jones`, `John`, `Smith`},
+		{`edited by John Smith and Mary Jones; translated by Bob Brown`, `Edited by John Smith and Mary Jones; translated by Bob Brown`, `John`, `Smith`},
+	}
+	for _, test := range authorTests {
+		out, a := Author(test.in, Language_English)
+		if out != test.out || a.First != test.first || a.Middle != `` || a.Last != test.last || a.Role != `editor` {
+			t.Errorf("%q = %q, First %q, Middle %q, Last %q, Role %q, want %q, %q, %q, %q, editor", test.in, out, a.First, a.Middle, a.Last, a.Role, test.out, test.first, ``, test.last)
+		}
+	}
+}
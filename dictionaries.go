@@ -0,0 +1,259 @@
+package titlecase
+
+import (
+ "unicode"
+ "github.com/AlasdairF/BinSearch"
+)
+
+// A list of words kept alongside the binary search structure built from it, so that it can be rebuilt when words are added or removed
+type keyList struct {
+ binsearch.KeyRunes
+ words [][]rune
+}
+
+// As keyList, but each word also has a display form
+type formatList struct {
+ keyList
+ display [][]rune // in the same order as words
+ format [][]rune // in the order of the built structure
+}
+
+// Dictionaries holds the word lists used by a Formatter. Use NewDictionaries to get a copy of the built-in lists that can be modified.
+// Dictionaries must not be modified while a Formatter using them is in use.
+type Dictionaries struct {
+ romanExceptions, makecaps, titlesabv, titles, multilast, corporate, familyFirst keyList
+ small [Language_Portuguese + 1]keyList // indexed by language
+ honor formatList
+ display formatList // words with their own casing, e.g. iPhone, McGraw, GmbH
+}
+
+func (k *keyList) build() {
+	k.KeyRunes = binsearch.KeyRunes{}
+	if len(k.words) == 0 {
+		return
+	}
+	for _, word := range k.words {
+		k.AddUnsorted(word)
+	}
+	k.Build()
+	k.Optimize()
+}
+
+func (k *formatList) build() {
+	k.KeyRunes = binsearch.KeyRunes{}
+	k.format = nil
+	if len(k.words) == 0 {
+		return
+	}
+	for _, word := range k.words {
+		k.AddUnsorted(word)
+	}
+	newindexes, _ := k.Build()
+	k.format = make([][]rune, len(newindexes))
+	for indx_new, indx_old := range newindexes {
+		k.format[indx_new] = k.display[indx_old]
+	}
+	k.Optimize()
+}
+
+func (k *keyList) index(word []rune) int {
+	for i, w := range k.words {
+		if equal(w, word) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (k *keyList) add(words []string, key func(string) []rune) {
+	var word []rune
+	for _, str := range words {
+		word = key(str)
+		if len(word) > 0 && k.index(word) == -1 {
+			k.words = append(k.words, word)
+		}
+	}
+	k.build()
+}
+
+func (k *keyList) remove(words []string, key func(string) []rune) {
+	var i int
+	for _, str := range words {
+		if i = k.index(key(str)); i > -1 {
+			k.words = append(k.words[0:i], k.words[i+1:]...)
+		}
+	}
+	k.build()
+}
+
+func (k *formatList) set(str string, display string) {
+	word := lowerKey(str)
+	if len(word) == 0 {
+		return
+	}
+	if i := k.index(word); i > -1 {
+		k.display[i] = []rune(display)
+	} else {
+		k.words = append(k.words, word)
+		k.display = append(k.display, []rune(display))
+	}
+	k.build()
+}
+
+func (k *formatList) remove(words []string) {
+	var i int
+	for _, str := range words {
+		if i = k.index(lowerKey(str)); i > -1 {
+			k.words = append(k.words[0:i], k.words[i+1:]...)
+			k.display = append(k.display[0:i], k.display[i+1:]...)
+		}
+	}
+	k.build()
+}
+
+func (k keyList) clone() keyList {
+	k.words = append([][]rune(nil), k.words...)
+	return k
+}
+
+func (k formatList) clone() formatList {
+	k.keyList = k.keyList.clone()
+	k.display = append([][]rune(nil), k.display...)
+	return k
+}
+
+// Words are looked up in lowercase
+func lowerKey(str string) []rune {
+	word := []rune(str)
+	for i, r := range word {
+		word[i] = unicode.ToLower(r)
+	}
+	return word
+}
+
+// NewDictionaries returns a copy of the built-in dictionaries, which can be modified and passed to New in Options.
+func NewDictionaries() *Dictionaries {
+	d := defaultDictionaries
+	c := &Dictionaries{
+		romanExceptions: d.romanExceptions.clone(),
+		makecaps: d.makecaps.clone(),
+		titlesabv: d.titlesabv.clone(),
+		titles: d.titles.clone(),
+		multilast: d.multilast.clone(),
+		corporate: d.corporate.clone(),
+		familyFirst: d.familyFirst.clone(),
+		honor: d.honor.clone(),
+		display: d.display.clone(),
+	}
+	for i := range d.small {
+		c.small[i] = d.small[i].clone()
+	}
+	return c
+}
+
+// AddSmallWords adds words that are kept lowercase in titles of the given language. Unknown languages are ignored.
+func (d *Dictionaries) AddSmallWords(language uint8, words ...string) {
+	if int(language) < len(d.small) {
+		d.small[language].add(words, lowerKey)
+	}
+}
+
+// RemoveSmallWords removes words from the small words of the given language, so they are capitalized in titles.
+func (d *Dictionaries) RemoveSmallWords(language uint8, words ...string) {
+	if int(language) < len(d.small) {
+		d.small[language].remove(words, lowerKey)
+	}
+}
+
+// AddCaps adds words that are always uppercased, such as acronyms.
+func (d *Dictionaries) AddCaps(words ...string) {
+	d.makecaps.add(words, lowerKey)
+}
+
+// RemoveCaps removes words that are always uppercased.
+func (d *Dictionaries) RemoveCaps(words ...string) {
+	d.makecaps.remove(words, lowerKey)
+}
+
+// AddRomanExceptions adds words made only of Roman numeral letters that are not Roman numerals.
+func (d *Dictionaries) AddRomanExceptions(words ...string) {
+	d.romanExceptions.add(words, lowerKey)
+}
+
+// RemoveRomanExceptions removes exceptions, so the words are treated as Roman numerals again.
+func (d *Dictionaries) RemoveRomanExceptions(words ...string) {
+	d.romanExceptions.remove(words, lowerKey)
+}
+
+// AddTitleAbbreviations adds abbreviated titles (Mr, Dr, Capt, etc.), without the period.
+func (d *Dictionaries) AddTitleAbbreviations(words ...string) {
+	d.titlesabv.add(words, lowerKey)
+}
+
+// RemoveTitleAbbreviations removes abbreviated titles, without the period.
+func (d *Dictionaries) RemoveTitleAbbreviations(words ...string) {
+	d.titlesabv.remove(words, lowerKey)
+}
+
+// AddTitles adds titles (Sir, Lord, Bishop, etc.) that are separated from an author's name. Titles of up to three words are written with single spaces, e.g. "His Royal Highness".
+func (d *Dictionaries) AddTitles(words ...string) {
+	d.titles.add(words, lowerKey)
+}
+
+// RemoveTitles removes titles that are separated from an author's name.
+func (d *Dictionaries) RemoveTitles(words ...string) {
+	d.titles.remove(words, lowerKey)
+}
+
+// AddSurnameParticles adds words that join a surname and are kept lowercase in author names (de, von, van, etc.)
+func (d *Dictionaries) AddSurnameParticles(words ...string) {
+	d.multilast.add(words, lowerKey)
+}
+
+// RemoveSurnameParticles removes surname particles, so they are capitalized like the rest of the name.
+func (d *Dictionaries) RemoveSurnameParticles(words ...string) {
+	d.multilast.remove(words, lowerKey)
+}
+
+// AddFamilyFirstNames adds family names that are written before the given name, used to find the name order when NameOrder is NameOrder_Auto.
+func (d *Dictionaries) AddFamilyFirstNames(words ...string) {
+	d.familyFirst.add(words, lowerKey)
+}
+
+// RemoveFamilyFirstNames removes family names that are written before the given name.
+func (d *Dictionaries) RemoveFamilyFirstNames(words ...string) {
+	d.familyFirst.remove(words, lowerKey)
+}
+
+// AddCorporateWords adds words that identify an author as a corporate body rather than a person (Society, University, GmbH, etc.)
+func (d *Dictionaries) AddCorporateWords(words ...string) {
+	d.corporate.add(words, lowerKey)
+}
+
+// RemoveCorporateWords removes words that identify an author as a corporate body.
+func (d *Dictionaries) RemoveCorporateWords(words ...string) {
+	d.corporate.remove(words, lowerKey)
+}
+
+// AddHonor adds an academic honor or dotted abbreviation and the way it should be displayed, e.g. AddHonor("ph.d", "Ph.D").
+// Only words that contain punctuation are looked up as honors.
+func (d *Dictionaries) AddHonor(word string, display string) {
+	d.honor.set(word, display)
+}
+
+// RemoveHonors removes academic honors and dotted abbreviations, given in any case, e.g. RemoveHonors("ph.d").
+func (d *Dictionaries) RemoveHonors(words ...string) {
+	d.honor.remove(words)
+}
+
+// AddDisplayForms adds words that are always displayed exactly as given, regardless of the case of the input, e.g. AddDisplayForms("iPhone", "McGraw", "GmbH").
+func (d *Dictionaries) AddDisplayForms(words ...string) {
+	for _, word := range words {
+		d.display.set(word, word)
+	}
+}
+
+// RemoveDisplayForms removes words that are always displayed exactly as given. The words can be given in any case.
+func (d *Dictionaries) RemoveDisplayForms(words ...string) {
+	d.display.remove(words)
+}
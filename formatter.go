@@ -0,0 +1,79 @@
+package titlecase
+
+const (
+ Punctuation_Clean		= 0 // trim surrounding punctuation and normalize brackets (default)
+ Punctuation_Keep		= 1 // leave surrounding punctuation and brackets as they are
+)
+
+const (
+ Case_Title		= 0 // capitalize all words except small words (default)
+ Case_Sentence	= 1 // capitalize only the first word of each sentence, and words that are always capitalized (Roman numerals, abbreviations, honors, titles), keeping words typed with capitals in mixed case input
+)
+
+const (
+ NameOrder_Auto		= 0 // family name first if the first word is a known family name from a culture that puts it first, e.g. Mao Zedong (default)
+ NameOrder_Western	= 1 // given names first unless there is a comma
+ NameOrder_Eastern	= 2 // family name first unless there is a comma, e.g. Chinese, Japanese, Korean, Vietnamese and Hungarian names
+)
+
+const (
+ ParticleCase_Lower	= 0 // surname particles are lowercase unless they begin the name, e.g. Vincent van Gogh, Van Gogh, Vincent (default)
+ ParticleCase_Upper	= 1 // the first particle is always capitalized, as in Belgian names, e.g. Herman Van Rompuy
+ ParticleCase_Keep	= 2 // particles keep the case they were typed in, for lists that mix both
+)
+
+// Options configures a Formatter. The zero value formats titles using the Generic language rules and the built-in dictionaries.
+type Options struct {
+ Language uint8
+ Author bool // format as an author name instead of a title
+ Punctuation uint8
+ Case uint8 // ignored when formatting authors
+ Style uint8 // style preset for English titles
+ PreserveMixedCase bool // keep words with internal capitals (iPhone, LaTeX), and in titles words in uppercase (NATO), as typed, unless the input is all uppercase or all lowercase
+ Acronyms bool // uppercase words that the heuristics in acronym.go find are likely to be acronyms
+ AcronymThreshold float64 // confidence needed for Acronyms, between 0 and 1; 0 uses DefaultAcronymThreshold
+ NameOrder uint8 // order of the names in authors without a comma
+ ParticleCase uint8 // capitalization of surname particles such as van, de and von
+ Dictionaries *Dictionaries // nil uses the built-in dictionaries
+}
+
+// Formatter is a configured title cleaner. It is not modified after creation and is safe for concurrent use, provided its Dictionaries are not modified while it is in use.
+type Formatter struct {
+ opt Options
+ dict *Dictionaries
+}
+
+func New(opt Options) *Formatter {
+	f := &Formatter{opt: opt, dict: opt.Dictionaries}
+	if f.dict == nil {
+		f.dict = defaultDictionaries
+	}
+	return f
+}
+
+func newFormatter(language uint8) *Formatter {
+	return &Formatter{opt: Options{Language: language}, dict: defaultDictionaries}
+}
+
+// Options returns the options the Formatter was created with.
+func (f *Formatter) Options() Options {
+	return f.opt
+}
+
+// Format formats str as a title, or as an author name if the Author option is set.
+func (f *Formatter) Format(str string) string {
+	str, _ = f.format(str, f.opt.Author)
+	return str
+}
+
+// Author formats str as an author name and splits it into its parts, regardless of the Author option.
+func (f *Formatter) Author(str string) (string, *AuthorStruct) {
+	return f.format(str, true)
+}
+
+// FormatAcronyms is as Format, and also returns the words that were uppercased by the acronym heuristics and which heuristics fired.
+func (f *Formatter) FormatAcronyms(str string) (string, []Acronym) {
+	var acronyms []Acronym
+	str, _ = f.formatWords(str, f.opt.Author, &acronyms)
+	return str, acronyms
+}
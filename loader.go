@@ -0,0 +1,472 @@
+package titlecase
+
+import (
+ "os"
+ "io"
+ "io/fs"
+ "bufio"
+ "bytes"
+ "sort"
+ "errors"
+ "strconv"
+ "strings"
+ "path/filepath"
+ "encoding/json"
+)
+
+/*
+
+Dictionary files
+
+TSV files have one entry per line, with the fields separated by tabs. Blank lines and lines beginning with # are ignored.
+
+	small	en	via
+	caps	naacp
+	roman	mix
+	honor	ph.d	Ph.D
+	display	iPhone
+
+JSON files hold the same entries in one object:
+
+	{
+	 "small": {"en": ["via"]},
+	 "caps": ["naacp"],
+	 "roman": ["mix"],
+	 "honor": {"ph.d": "Ph.D"},
+	 "display": ["iPhone"]
+	}
+
+Languages are given by code (en, fr, de, it, es, pt, generic) or name (english, french, ...).
+
+*/
+
+const (
+ entrySmall = iota
+ entryCaps
+ entryRoman
+ entryHonor
+ entryDisplay
+)
+
+var entryNames = []string{`small`, `caps`, `roman`, `honor`, `display`}
+
+type entry struct {
+ kind uint8
+ language uint8
+ word string
+ display string
+ line int
+ name string // the file the entry is from
+}
+
+// Where the entry is, for reporting a conflict with it, e.g. line 3 or caps.tsv:3
+func (e *entry) position() string {
+	if len(e.name) > 0 {
+		return e.name + `:` + strconv.Itoa(e.line)
+	}
+	return `line ` + strconv.Itoa(e.line)
+}
+
+// LoadError reports a problem with an entry in a dictionary file
+type LoadError struct {
+ Name string
+ Line int
+ Err string
+}
+
+func (e LoadError) Error() string {
+	if len(e.Name) > 0 {
+		return e.Name + `:` + strconv.Itoa(e.Line) + `: ` + e.Err
+	}
+	return `line ` + strconv.Itoa(e.Line) + `: ` + e.Err
+}
+
+// LoadErrors is returned when one or more entries are invalid, in which case nothing is loaded
+type LoadErrors []LoadError
+
+func (e LoadErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+type loader struct {
+ name string // the file being read
+ names []string // every file read, in order
+ entries []entry
+ errs LoadErrors
+}
+
+func (l *loader) fail(line int, msg string) {
+	l.errs = append(l.errs, LoadError{l.name, line, msg})
+}
+
+func (l *loader) failEntry(e *entry, msg string) {
+	l.errs = append(l.errs, LoadError{e.name, e.line, msg})
+}
+
+func languageCode(code string) (uint8, bool) {
+	switch strings.ToLower(code) {
+		case `generic`, `und`, `0`: return Language_Generic, true
+		case `en`, `eng`, `english`, `1`: return Language_English, true
+		case `fr`, `fre`, `fra`, `french`, `2`: return Language_French, true
+		case `de`, `ger`, `deu`, `german`, `3`: return Language_German, true
+		case `it`, `ita`, `italian`, `4`: return Language_Italian, true
+		case `es`, `spa`, `spanish`, `5`: return Language_Spanish, true
+		case `pt`, `por`, `portuguese`, `6`: return Language_Portuguese, true
+	}
+	return 0, false
+}
+
+func (l *loader) readTSV(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	var line int
+	var fields []string
+	var language uint8
+	var ok bool
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(text)) == 0 || text[0] == '#' {
+			continue
+		}
+		fields = strings.Split(text, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		switch strings.ToLower(fields[0]) {
+			case `small`:
+				if len(fields) != 3 {
+					l.fail(line, `small words need a language and a word`)
+					continue
+				}
+				if language, ok = languageCode(fields[1]); !ok {
+					l.fail(line, `unknown language "` + fields[1] + `"`)
+					continue
+				}
+				l.entries = append(l.entries, entry{entrySmall, language, fields[2], ``, line, l.name})
+			case `caps`, `roman`, `display`:
+				if len(fields) != 2 {
+					l.fail(line, fields[0] + ` entries need exactly one word`)
+					continue
+				}
+				switch strings.ToLower(fields[0]) {
+					case `caps`: l.entries = append(l.entries, entry{entryCaps, 0, fields[1], ``, line, l.name})
+					case `roman`: l.entries = append(l.entries, entry{entryRoman, 0, fields[1], ``, line, l.name})
+					case `display`: l.entries = append(l.entries, entry{entryDisplay, 0, fields[1], fields[1], line, l.name})
+				}
+			case `honor`:
+				if len(fields) != 3 {
+					l.fail(line, `honors need a word and its display form`)
+					continue
+				}
+				l.entries = append(l.entries, entry{entryHonor, 0, fields[1], fields[2], line, l.name})
+			default:
+				l.fail(line, `unknown entry type "` + fields[0] + `"`)
+		}
+	}
+	return scanner.Err()
+}
+
+func (l *loader) readJSON(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	lineAt := func() int {
+		return bytes.Count(data[0:dec.InputOffset()], []byte{'\n'}) + 1
+	}
+	var tok json.Token
+	next := func() (json.Token, error) {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, LoadError{l.name, lineAt(), err.Error()}
+		}
+		return tok, nil
+	}
+	expect := func(want json.Delim) error {
+		if tok, err = next(); err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); !ok || d != want {
+			return LoadError{l.name, lineAt(), `expected ` + want.String()}
+		}
+		return nil
+	}
+	str := func() (string, error) {
+		if tok, err = next(); err != nil {
+			return ``, err
+		}
+		s, ok := tok.(string)
+		if !ok {
+			return ``, LoadError{l.name, lineAt(), `expected a string`}
+		}
+		return s, nil
+	}
+	// A list of words, read into entries of the given kind
+	list := func(kind uint8, language uint8) error {
+		if err := expect('['); err != nil {
+			return err
+		}
+		for dec.More() {
+			word, err := str()
+			if err != nil {
+				return err
+			}
+			l.entries = append(l.entries, entry{kind, language, word, ``, lineAt(), l.name})
+		}
+		return expect(']')
+	}
+
+	if err = expect('{'); err != nil {
+		return err
+	}
+	var section, key, value string
+	var language uint8
+	var ok bool
+	for dec.More() {
+		if section, err = str(); err != nil {
+			return err
+		}
+		switch strings.ToLower(section) {
+			case `small`:
+				if err = expect('{'); err != nil {
+					return err
+				}
+				for dec.More() {
+					if key, err = str(); err != nil {
+						return err
+					}
+					if language, ok = languageCode(key); !ok {
+						return LoadError{l.name, lineAt(), `unknown language "` + key + `"`}
+					}
+					if err = list(entrySmall, language); err != nil {
+						return err
+					}
+				}
+				if err = expect('}'); err != nil {
+					return err
+				}
+			case `caps`:
+				if err = list(entryCaps, 0); err != nil {
+					return err
+				}
+			case `roman`:
+				if err = list(entryRoman, 0); err != nil {
+					return err
+				}
+			case `display`:
+				if err = list(entryDisplay, 0); err != nil {
+					return err
+				}
+			case `honor`:
+				if err = expect('{'); err != nil {
+					return err
+				}
+				for dec.More() {
+					if key, err = str(); err != nil {
+						return err
+					}
+					if value, err = str(); err != nil {
+						return err
+					}
+					l.entries = append(l.entries, entry{entryHonor, 0, key, value, lineAt(), l.name})
+				}
+				if err = expect('}'); err != nil {
+					return err
+				}
+			default:
+				return LoadError{l.name, lineAt(), `unknown section "` + section + `"`}
+		}
+	}
+	return expect('}')
+}
+
+// Checks the entries for duplicates and conflicts, with each other and with the dictionaries they will be loaded into
+func (l *loader) validate(d *Dictionaries) {
+	type seenKey struct {
+	 kind uint8
+	 language uint8
+	 word string
+	}
+	seen := make(map[seenKey]*entry)
+	small := make(map[string]*entry)
+	caps := make(map[string]*entry)
+	var ok bool
+	var first *entry
+	for i := range l.entries {
+		e := &l.entries[i]
+		e.word = strings.TrimSpace(e.word)
+		if len(e.word) == 0 {
+			l.failEntry(e, `empty ` + entryNames[e.kind] + ` entry`)
+			continue
+		}
+		word := lowerKey(e.word)
+		key := seenKey{e.kind, e.language, string(word)}
+		if first, ok = seen[key]; ok {
+			l.failEntry(e, `duplicate ` + entryNames[e.kind] + ` entry "` + e.word + `", first on ` + first.position())
+			continue
+		}
+		seen[key] = e
+		switch e.kind {
+			case entrySmall:
+				if first, ok = caps[key.word]; ok {
+					l.failEntry(e, `"` + e.word + `" is both a small word and caps on ` + first.position())
+				} else if _, ok = d.makecaps.Find(word); ok {
+					l.failEntry(e, `"` + e.word + `" is a small word but is already in caps`)
+				}
+				if _, ok = small[key.word]; !ok {
+					small[key.word] = e
+				}
+			case entryCaps:
+				if first, ok = small[key.word]; ok {
+					l.failEntry(e, `"` + e.word + `" is both caps and a small word on ` + first.position())
+				} else {
+					for j := range d.small {
+						if _, ok = d.small[j].Find(word); ok {
+							l.failEntry(e, `"` + e.word + `" is caps but is already a small word`)
+							break
+						}
+					}
+				}
+				caps[key.word] = e
+			case entryRoman:
+				for _, r := range word {
+					switch r {
+						case 'i', 'v', 'x', 'm', 'c', 'd', 'l':
+							continue
+					}
+					l.failEntry(e, `"` + e.word + `" is not made of Roman numerals so cannot be an exception`)
+					break
+				}
+			case entryHonor:
+				if !strings.ContainsAny(e.word, `.,;:!?&`) {
+					l.failEntry(e, `honor "` + e.word + `" contains no punctuation so would never be matched`)
+				} else if !equal(lowerKey(e.display), word) {
+					l.failEntry(e, `honor "` + e.word + `" has conflicting display form "` + e.display + `"`)
+				}
+		}
+	}
+}
+
+func (l *loader) apply(d *Dictionaries) {
+	var small [Language_Portuguese + 1][]string
+	var caps, roman, display []string
+	for _, e := range l.entries {
+		switch e.kind {
+			case entrySmall: small[e.language] = append(small[e.language], e.word)
+			case entryCaps: caps = append(caps, e.word)
+			case entryRoman: roman = append(roman, e.word)
+			case entryHonor: d.AddHonor(e.word, e.display)
+			case entryDisplay: display = append(display, e.word)
+		}
+	}
+	for i, words := range small {
+		if len(words) > 0 {
+			d.AddSmallWords(uint8(i), words...)
+		}
+	}
+	if len(caps) > 0 {
+		d.AddCaps(caps...)
+	}
+	if len(roman) > 0 {
+		d.AddRomanExceptions(roman...)
+	}
+	if len(display) > 0 {
+		d.AddDisplayForms(display...)
+	}
+}
+
+// Reads the entries in a file, which are only checked and added by commit, so that several files are added together or not at all
+func (l *loader) read(name string, r io.Reader, isJSON bool) error {
+	l.name = name
+	l.names = append(l.names, name)
+	var err error
+	if isJSON {
+		err = l.readJSON(r)
+	} else {
+		err = l.readTSV(r)
+	}
+	if err != nil {
+		var lerr LoadError
+		if errors.As(err, &lerr) {
+			return LoadErrors{lerr}
+		}
+		return err
+	}
+	return nil
+}
+
+// Checks the entries of every file read, and adds them to the dictionaries if there are no errors
+func (l *loader) commit(d *Dictionaries) error {
+	l.validate(d)
+	if len(l.errs) > 0 {
+		order := make(map[string]int, len(l.names))
+		for i, name := range l.names {
+			order[name] = i
+		}
+		sort.SliceStable(l.errs, func(i, j int) bool {
+			if l.errs[i].Name != l.errs[j].Name {
+				return order[l.errs[i].Name] < order[l.errs[j].Name]
+			}
+			return l.errs[i].Line < l.errs[j].Line
+		})
+		return l.errs
+	}
+	l.apply(d)
+	return nil
+}
+
+func (d *Dictionaries) load(name string, r io.Reader, isJSON bool) error {
+	l := new(loader)
+	if err := l.read(name, r, isJSON); err != nil {
+		return err
+	}
+	return l.commit(d)
+}
+
+// Reads all the named files before adding any of them, so nothing is added if any file has an error or two files conflict
+func (d *Dictionaries) loadAll(open func(string) (io.ReadCloser, error), names []string) error {
+	l := new(loader)
+	for _, name := range names {
+		fi, err := open(name)
+		if err != nil {
+			return err
+		}
+		err = l.read(name, fi, strings.EqualFold(filepath.Ext(name), `.json`))
+		fi.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return l.commit(d)
+}
+
+// LoadTSV adds the entries in a TSV dictionary file. If any entry is invalid a LoadErrors is returned and nothing is added.
+func (d *Dictionaries) LoadTSV(r io.Reader) error {
+	return d.load(``, r, false)
+}
+
+// LoadJSON adds the entries in a JSON dictionary file. If any entry is invalid a LoadErrors is returned and nothing is added.
+func (d *Dictionaries) LoadJSON(r io.Reader) error {
+	return d.load(``, r, true)
+}
+
+// LoadFS adds the entries in the named files from fsys. Files ending in .json are read as JSON, all others as TSV.
+// If any file cannot be read, any entry is invalid, or two files conflict, an error is returned and nothing is added from any of the files.
+func (d *Dictionaries) LoadFS(fsys fs.FS, names ...string) error {
+	return d.loadAll(func(name string) (io.ReadCloser, error) {
+		return fsys.Open(name)
+	}, names)
+}
+
+// LoadFiles adds the entries in the named files. Files ending in .json are read as JSON, all others as TSV.
+// If any file cannot be read, any entry is invalid, or two files conflict, an error is returned and nothing is added from any of the files.
+func (d *Dictionaries) LoadFiles(names ...string) error {
+	return d.loadAll(func(name string) (io.ReadCloser, error) {
+		return os.Open(name)
+	}, names)
+}
@@ -0,0 +1,99 @@
+package titlecase
+
+import (
+ "errors"
+ "strings"
+ "testing"
+ "testing/fstest"
+)
+
+func TestLoadFSAllOrNothing(t *testing.T) {
+	fsys := fstest.MapFS{
+		`a.tsv`: {Data: []byte("caps\txyzzy\nsmall\ten\tvia\n")},
+		`b.json`: {Data: []byte(`{"caps": ["plugh"`)},
+		`c.tsv`: {Data: []byte("caps\tvia\n")},
+		`d.json`: {Data: []byte(`{"caps": ["plugh"], "display": ["eFoo"]}`)},
+	}
+	loaded := func(d *Dictionaries, word string) bool {
+		_, ok := d.makecaps.Find(lowerKey(word))
+		return ok
+	}
+
+	d := NewDictionaries()
+	if err := d.LoadFS(fsys, `a.tsv`, `b.json`); err == nil {
+		t.Errorf("a.tsv and b.json: no error")
+	}
+	if loaded(d, `xyzzy`) {
+		t.Errorf("a.tsv was loaded although b.json failed")
+	}
+
+	d = NewDictionaries()
+	err := d.LoadFS(fsys, `a.tsv`, `c.tsv`)
+	var lerrs LoadErrors
+	if !errors.As(err, &lerrs) || len(lerrs) != 1 || lerrs[0].Name != `c.tsv` || !strings.Contains(lerrs[0].Err, `a.tsv:2`) {
+		t.Errorf("a.tsv and c.tsv: %v", err)
+	}
+	if loaded(d, `xyzzy`) {
+		t.Errorf("a.tsv was loaded although it conflicts with c.tsv")
+	}
+
+	d = NewDictionaries()
+	if err = d.LoadFS(fsys, `a.tsv`, `d.json`); err != nil {
+		t.Fatalf("a.tsv and d.json: %v", err)
+	}
+	if !loaded(d, `xyzzy`) || !loaded(d, `plugh`) {
+		t.Errorf("a.tsv and d.json were not both loaded")
+	}
+	if got := New(Options{Language: Language_English, Dictionaries: d}).Format(`the efoo of xyzzy`); got != `The eFoo of XYZZY` {
+		t.Errorf("after loading: %q", got)
+	}
+}
+
+func TestDictionaryEdits(t *testing.T) {
+	tests := []struct {
+		edit func(d *Dictionaries)
+		author bool
+		in, before, after string
+	}{
+		{func(d *Dictionaries) { d.AddSmallWords(Language_English, `via`, `versus`) }, false, `travels via rome versus paris`, `Travels Via Rome Versus Paris`, `Travels via Rome versus Paris`},
+		{func(d *Dictionaries) { d.RemoveSmallWords(Language_English, `the`) }, false, `gone with the wind`, `Gone With the Wind`, `Gone With The Wind`},
+		{func(d *Dictionaries) { d.AddCaps(`xyzzy`) }, false, `the xyzzy report`, `The Xyzzy Report`, `The XYZZY Report`},
+		{func(d *Dictionaries) { d.RemoveCaps(`usa`) }, false, `made in usa`, `Made in USA`, `Made in Usa`},
+		{func(d *Dictionaries) { d.AddRomanExceptions(`xi`) }, false, `the xi factor`, `The XI Factor`, `The Xi Factor`},
+		{func(d *Dictionaries) { d.RemoveRomanExceptions(`civil`) }, false, `civil war`, `Civil War`, `CIVIL War`},
+		{func(d *Dictionaries) { d.AddTitleAbbreviations(`insp`) }, true, `insp john smith`, `Insp John Smith`, `Insp. John Smith`},
+		{func(d *Dictionaries) { d.AddSurnameParticles(`ap`) }, true, `dafydd ap gwilym`, `Dafydd Ap Gwilym`, `Dafydd ap Gwilym`},
+		{func(d *Dictionaries) { d.AddHonor(`b.litt`, `B.Litt`) }, false, `a b.litt thesis`, `A B. Litt Thesis`, `A B.Litt Thesis`},
+		{func(d *Dictionaries) { d.AddDisplayForms(`WordPress`) }, false, `blogging with wordpress`, `Blogging With Wordpress`, `Blogging With WordPress`},
+		{func(d *Dictionaries) { d.RemoveDisplayForms(`iphone`) }, false, `the iphone`, `The iPhone`, `The Iphone`},
+	}
+	for _, test := range tests {
+		d := NewDictionaries()
+		f := New(Options{Language: Language_English, Author: test.author, Dictionaries: d})
+		if got := f.Format(test.in); got != test.before {
+			t.Errorf("%q before the edit = %q, want %q", test.in, got, test.before)
+		}
+		test.edit(d)
+		if got := f.Format(test.in); got != test.after {
+			t.Errorf("%q after the edit = %q, want %q", test.in, got, test.after)
+		}
+		// The edits do not change the default dictionaries
+		if got := New(Options{Language: Language_English, Author: test.author}).Format(test.in); got != test.before {
+			t.Errorf("%q with the default dictionaries = %q, want %q", test.in, got, test.before)
+		}
+	}
+	// Titles only change how the name is split
+	d := NewDictionaries()
+	f := New(Options{Language: Language_English, Author: true, Dictionaries: d})
+	if _, a := f.Author(`Archdeacon John Smith`); a.Title != `` || a.First != `Archdeacon` {
+		t.Errorf("before AddTitles: Title %q, First %q", a.Title, a.First)
+	}
+	d.AddTitles(`archdeacon`)
+	if _, a := f.Author(`Archdeacon John Smith`); a.Title != `Archdeacon` || a.First != `John` {
+		t.Errorf("after AddTitles: Title %q, First %q", a.Title, a.First)
+	}
+	d.RemoveTitles(`archdeacon`)
+	if _, a := f.Author(`Archdeacon John Smith`); a.Title != `` {
+		t.Errorf("after RemoveTitles: Title %q", a.Title)
+	}
+}
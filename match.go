@@ -0,0 +1,211 @@
+package titlecase
+
+import (
+ "strings"
+ "unicode"
+ "unicode/utf8"
+)
+
+// Folds a letter to lowercase ASCII, e.g. é -> e and ß -> ss. Letters without an ASCII form are only lowercased.
+func foldRune(r rune) string {
+	r = unicode.ToLower(r)
+	if r < 128 {
+		return string(r)
+	}
+	switch r {
+		case 'à', 'á', 'â', 'ã', 'ä', 'å', 'ā', 'ă', 'ą': return `a`
+		case 'æ': return `ae`
+		case 'ç', 'ć', 'ĉ', 'ċ', 'č': return `c`
+		case 'ď', 'đ', 'ð': return `d`
+		case 'è', 'é', 'ê', 'ë', 'ē', 'ĕ', 'ė', 'ę', 'ě': return `e`
+		case 'ĝ', 'ğ', 'ġ', 'ģ': return `g`
+		case 'ĥ', 'ħ': return `h`
+		case 'ì', 'í', 'î', 'ï', 'ĩ', 'ī', 'ĭ', 'į', 'ı': return `i`
+		case 'ĵ': return `j`
+		case 'ķ': return `k`
+		case 'ĺ', 'ļ', 'ľ', 'ŀ', 'ł': return `l`
+		case 'ñ', 'ń', 'ņ', 'ň': return `n`
+		case 'ò', 'ó', 'ô', 'õ', 'ö', 'ø', 'ō', 'ŏ', 'ő': return `o`
+		case 'œ': return `oe`
+		case 'ŕ', 'ŗ', 'ř': return `r`
+		case 'ś', 'ŝ', 'ş', 'š', 'ș': return `s`
+		case 'ß': return `ss`
+		case 'ţ', 'ť', 'ŧ', 'ț': return `t`
+		case 'þ': return `th`
+		case 'ù', 'ú', 'û', 'ü', 'ũ', 'ū', 'ŭ', 'ů', 'ű', 'ų': return `u`
+		case 'ŵ': return `w`
+		case 'ý', 'ÿ', 'ŷ': return `y`
+		case 'ź', 'ż', 'ž': return `z`
+	}
+	return string(r)
+}
+
+// Folds a name to lowercase ASCII words separated by single spaces, e.g. García-Márquez -> garcia marquez
+func foldName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range name {
+		if r == '\'' || r == '’' { // O'Brien -> obrien
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteString(foldRune(r))
+	}
+	return b.String()
+}
+
+// Returns the folded given names, with each initial as a single letter, e.g. J.R.R. -> j, r, r.
+// The fuller form is used instead when it agrees with the given names, e.g. John Ronald Reuel for J. R. R., but not Bob for Robert.
+func (a AuthorStruct) givenNames() []string {
+	names := strings.Fields(foldName(a.First + ` ` + a.Middle))
+	fuller := strings.Fields(foldName(a.FullerForm))
+	if len(fuller) == 0 || len(fuller) < len(names) {
+		return names
+	}
+	for i, name := range names {
+		if name != fuller[i] && !isInitialOf(name, fuller[i]) {
+			return names
+		}
+	}
+	return fuller
+}
+
+// Returns the folded given names and surnames, leaving out lowercase particles, e.g. gabriel and garcia, marquez for García Márquez, Gabriel
+func (a AuthorStruct) matchNames() ([]string, []string) {
+	var surnames []string
+	for _, word := range strings.Fields(a.Last) {
+		if r, _ := utf8.DecodeRuneInString(word); !unicode.IsLower(r) {
+			surnames = append(surnames, strings.Fields(foldName(word))...)
+		}
+	}
+	return a.givenNames(), surnames
+}
+
+// Whether the first surname is the main one, as Spanish names are often cited without the second, e.g. Gabriel García for Gabriel García Márquez
+func (a AuthorStruct) firstSurnameFirst() bool {
+	return a.language == Language_Spanish
+}
+
+// Whether a is the initial of b, e.g. j and john
+func isInitialOf(a, b string) bool {
+	return utf8.RuneCountInString(a) == 1 && strings.HasPrefix(b, a)
+}
+
+// Whether a begins with all the words of b, or b with all the words of a
+func hasPrefixWords(a, b []string) bool {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i := range b {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchKey returns a key for finding authors that may be the same person, made of the main surname folded to ASCII and the initials of the other names,
+// e.g. tolkien jrr for J. R. R. Tolkien and Tolkien, John Ronald Reuel, and marquez gg for García Márquez, Gabriel and Gabriel Garcia Marquez.
+// The main surname is the last, except for names read as Spanish, where it is the first and the other surnames are left out, so García Márquez, Gabriel and Gabriel García both give garcia g.
+// Names with the same key should be compared with CompareAuthors, since missing middle names give different keys.
+func (a AuthorStruct) MatchKey() string {
+	if len(a.Corporate) > 0 {
+		return foldName(a.Corporate)
+	}
+	names, surnames := a.matchNames()
+	var surname string
+	if len(surnames) > 0 {
+		if a.firstSurnameFirst() {
+			surname = surnames[0]
+		} else {
+			surname = surnames[len(surnames)-1]
+			names = append(names, surnames[0:len(surnames)-1]...)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(surname)
+	b.WriteByte(' ')
+	for _, name := range names {
+		r, _ := utf8.DecodeRuneInString(name)
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// CompareAuthors scores how likely it is that two authors are the same person, from 1 for the same name down to 0 if the names conflict.
+// An initial matches a full name that begins with it, and missing middle names, surnames or generations lower the score without ruling out a match.
+// The surnames match if the last are the same, however the names were grouped, or if one author has only the first of the other's surnames, e.g. Gabriel García and Gabriel García Márquez.
+func CompareAuthors(a, b AuthorStruct) float64 {
+	if len(a.Corporate) > 0 || len(b.Corporate) > 0 {
+		if foldName(a.Corporate) == foldName(b.Corporate) {
+			return 1
+		}
+		return 0
+	}
+	names1, surnames1 := a.matchNames()
+	names2, surnames2 := b.matchNames()
+	if len(surnames1) == 0 || len(surnames2) == 0 {
+		return 0
+	}
+	score := 1.0
+	switch {
+		case surnames1[len(surnames1)-1] == surnames2[len(surnames2)-1]:
+			names1 = append(names1, surnames1[0:len(surnames1)-1]...)
+			names2 = append(names2, surnames2[0:len(surnames2)-1]...)
+		case hasPrefixWords(surnames1, surnames2): // a missing second surname
+			missing := len(surnames1) - len(surnames2)
+			if missing < 0 {
+				missing = -missing
+			}
+			for ; missing > 0; missing-- {
+				score *= 0.8
+			}
+		default:
+			return 0
+	}
+
+	// Jr. and Sr. are different people
+	ga, gb := foldName(a.Generation()), foldName(b.Generation())
+	if ga != gb {
+		if len(ga) > 0 && len(gb) > 0 {
+			return 0
+		}
+		score *= 0.9
+	}
+
+	if len(names1) < len(names2) {
+		names1, names2 = names2, names1
+	}
+	if len(names2) == 0 {
+		if len(names1) > 0 {
+			score *= 0.6
+		}
+		return score
+	}
+	var n1, n2 string
+	for i := range names1 {
+		if i >= len(names2) { // a missing middle name
+			score *= 0.8
+			continue
+		}
+		n1, n2 = names1[i], names2[i]
+		switch {
+			case n1 == n2:
+				if utf8.RuneCountInString(n1) == 1 { // initials alone are weaker evidence
+					score *= 0.95
+				}
+			case isInitialOf(n1, n2), isInitialOf(n2, n1):
+				score *= 0.9
+			default:
+				return 0
+		}
+	}
+	return score
+}
@@ -0,0 +1,71 @@
+package titlecase
+
+import (
+ "testing"
+ "unicode/utf8"
+)
+
+func TestMatchKey(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in, key string
+	}{
+		{Language_English, `J. R. R. Tolkien`, `tolkien jrr`},
+		{Language_English, `Tolkien, John Ronald Reuel`, `tolkien jrr`},
+		{Language_English, `TOLKIEN, J.R.R.`, `tolkien jrr`},
+		{Language_English, `Tolkien, J. R. R. (John Ronald Reuel)`, `tolkien jrr`},
+		{Language_English, `García Márquez, Gabriel`, `marquez gg`},
+		{Language_English, `Gabriel Garcia Marquez`, `marquez gg`},
+		{Language_English, `Beethoven, Ludwig van`, `beethoven l`},
+		{Language_English, `Seán O'Brien`, `obrien s`},
+		{Language_English, `Толстой, Лев Николаевич`, `толстой лн`},
+		{Language_English, `Robert (Bob) Dylan`, `dylan r`},
+		{Language_Spanish, `García Márquez, Gabriel`, `garcia g`},
+		{Language_Spanish, `Gabriel García Márquez`, `garcia g`},
+		{Language_Spanish, `Gabriel García`, `garcia g`},
+		{Language_Portuguese, `João Cabral de Melo Neto`, `melo jc`},
+		{Language_Portuguese, `Melo Neto, João Cabral de`, `melo jc`},
+	}
+	for _, test := range tests {
+		_, a := Author(test.in, test.language)
+		key := a.MatchKey()
+		if key != test.key || !utf8.ValidString(key) {
+			t.Errorf("%q: MatchKey %q, want %q", test.in, key, test.key)
+		}
+	}
+}
+
+func TestCompareAuthors(t *testing.T) {
+	tests := []struct {
+		language uint8
+		a, b string
+		same bool
+	}{
+		{Language_English, `J. R. R. Tolkien`, `Tolkien, John Ronald Reuel`, true},
+		{Language_English, `TOLKIEN, J.R.R.`, `Tolkien, J. R. R. (John Ronald Reuel)`, true},
+		{Language_English, `John Tolkien`, `Tolkien, John Ronald Reuel`, true},
+		{Language_English, `García Márquez, Gabriel`, `Gabriel Garcia Marquez`, true},
+		{Language_English, `Толстой, Лев Николаевич`, `Толстой, Л. Н.`, true},
+		{Language_English, `Christopher Tolkien`, `J. R. R. Tolkien`, false},
+		{Language_English, `Martin Luther King Jr.`, `King, Martin Luther, Sr.`, false},
+		{Language_English, `Tolkien, John`, `Smith, John`, false},
+		{Language_English, `Robert (Bob) Dylan`, `Robert Dylan`, true},
+		{Language_English, `Robert (Bob) Dylan`, `Dylan, R.`, true},
+		{Language_Spanish, `Gabriel García`, `Gabriel García Márquez`, true},
+		{Language_Spanish, `García Márquez, Gabriel`, `Gabriel García`, true},
+		{Language_Portuguese, `João Cabral de Melo Neto`, `Melo Neto, João Cabral de`, true},
+		{Language_Portuguese, `João Cabral de Melo`, `João Melo`, true},
+	}
+	var a, b *AuthorStruct
+	for _, test := range tests {
+		_, a = Author(test.a, test.language)
+		_, b = Author(test.b, test.language)
+		if score := CompareAuthors(*a, *b); (score > 0) != test.same || (test.same && score > 1) {
+			t.Errorf("CompareAuthors(%q, %q) = %f", test.a, test.b, score)
+		}
+	}
+	_, a = Author(`Tolkien, John Ronald Reuel`, Language_English)
+	if score := CompareAuthors(*a, *a); score != 1 {
+		t.Errorf("CompareAuthors of the same name = %f, want 1", score)
+	}
+}
@@ -0,0 +1,259 @@
+package titlecase
+
+import (
+ "strings"
+ "unicode"
+)
+
+// Citation styles for rendering lists of authors with CiteAuthors
+const (
+ Citation_APA		= 0 // Smith, J. A., Jones, K., & Brown, M.
+ Citation_MLA		= 1 // Smith, John A., and Kate Jones
+ Citation_Chicago	= 2 // Smith, John A., Kate Jones, and Mark Brown
+ Citation_Vancouver	= 3 // Smith JA, Jones K, Brown M
+)
+
+// Joins the non-empty strings with sep
+func join(sep string, strs ...string) string {
+	var s string
+	for _, str := range strs {
+		if len(str) == 0 {
+			continue
+		}
+		if len(s) > 0 {
+			s += sep
+		}
+		s += str
+	}
+	return s
+}
+
+// Returns the initials of the given names, e.g. John Ronald -> J., R. and Jean-Paul -> J.-P., with or without the periods
+func initials(names string, period bool) []string {
+	var list []string
+	var initial string
+	for _, name := range strings.Fields(names) {
+		// J.R.R. is three initials
+		if strings.IndexByte(strings.TrimRight(name, `.`), '.') > -1 {
+			for _, part := range strings.Split(name, `.`) {
+				if len(part) > 0 {
+					list = append(list, initials(part, period)...)
+				}
+			}
+			continue
+		}
+		initial = ``
+		for i, part := range strings.Split(name, `-`) {
+			for _, r := range part {
+				if !unicode.IsLetter(r) {
+					continue
+				}
+				if i > 0 && period {
+					initial += `-`
+				}
+				initial += string(unicode.ToUpper(r))
+				if period {
+					initial += `.`
+				}
+				break
+			}
+		}
+		if len(initial) > 0 {
+			list = append(list, initial)
+		}
+	}
+	return list
+}
+
+// Whether a suffix is a generation, such as Jr., III or Filho, rather than an honor
+func isGeneration(suffix string) bool {
+	suffix = strings.ToLower(strings.Trim(suffix, `.,`))
+	if isGenerationWord([]rune(suffix)) {
+		return true
+	}
+	if len(suffix) == 0 {
+		return false
+	}
+	for _, r := range suffix {
+		switch r {
+			case 'i', 'v', 'x':
+				continue
+		}
+		return false
+	}
+	return true
+}
+
+// Generation returns the part of the suffix that is a generation, such as Jr. or III, leaving out any honors.
+func (a AuthorStruct) Generation() string {
+	var s string
+	for _, word := range strings.Fields(a.Suffix) {
+		if isGeneration(word) {
+			s = join(` `, s, word)
+		}
+	}
+	return s
+}
+
+// Adds the designation to a name, unless it is the whole name, e.g. Lord Byron
+func (a AuthorStruct) designate(name string) string {
+	if name == a.Designation {
+		return name
+	}
+	return join(`, `, name, a.Designation)
+}
+
+// Display returns the name in display order, e.g. Dr. John A. Smith Jr., Mao Zedong if the family name is written first, Herman "Babe" Ruth, or Arthur Wellesley, Duke of Wellington
+func (a AuthorStruct) Display() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	var nickname string
+	if len(a.Nickname) > 0 {
+		nickname = `"` + a.Nickname + `"`
+	}
+	if a.FamilyFirst {
+		return a.designate(join(` `, a.Title, a.Last, a.First, a.Middle, nickname, a.Suffix))
+	}
+	return a.designate(join(` `, a.Title, a.First, a.Middle, nickname, a.Last, a.Suffix))
+}
+
+// Inverted returns the name with the surname first, e.g. Smith, John A., Jr., Tolkien, J. R. R. (John Ronald Reuel) or Wellesley, Arthur, Duke of Wellington
+func (a AuthorStruct) Inverted() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	if len(a.Designation) > 0 && a.Designation == join(` `, a.Title, a.Last) {
+		return a.Designation
+	}
+	var fuller string
+	if len(a.FullerForm) > 0 {
+		fuller = `(` + a.FullerForm + `)`
+	}
+	return join(`, `, a.Last, join(` `, a.First, a.Middle, fuller), a.Suffix, a.Designation)
+}
+
+// APA returns the name as it is written in APA style, e.g. Smith, J. A., Jr.
+func (a AuthorStruct) APA() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	return join(`, `, a.Last, strings.Join(initials(a.First + ` ` + a.Middle, true), ` `), a.Generation())
+}
+
+// MLA returns the name as it is written in MLA style, e.g. Smith, John A., Jr.
+func (a AuthorStruct) MLA() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	return join(`, `, a.Last, join(` `, a.First, a.Middle), a.Generation())
+}
+
+// Chicago returns the name as it is written first in a Chicago style bibliography, e.g. Smith, John A., Jr.
+func (a AuthorStruct) Chicago() string {
+	return a.MLA()
+}
+
+// Vancouver returns the name as it is written in Vancouver style, e.g. Smith JA Jr
+func (a AuthorStruct) Vancouver() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	return join(` `, a.Last, strings.Join(initials(a.First + ` ` + a.Middle, false), ``), strings.Replace(a.Generation(), `.`, ``, -1))
+}
+
+// Sort returns the filing form of the name, with lowercase particles after the given names, e.g. Beethoven, Ludwig van
+func (a AuthorStruct) Sort() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	var particles string
+	last := strings.Fields(a.Last)
+	for len(last) > 1 && unicode.IsLower([]rune(last[0])[0]) {
+		particles = join(` `, particles, last[0])
+		last = last[1:]
+	}
+	return join(`, `, strings.Join(last, ` `), join(` `, a.First, a.Middle, particles), a.Generation())
+}
+
+// Renders an author that is not the first in the list, for styles that only invert the first author
+func (a AuthorStruct) notFirst() string {
+	if len(a.Corporate) > 0 {
+		return a.Corporate
+	}
+	if a.FamilyFirst {
+		return join(` `, a.Last, a.First, a.Middle)
+	}
+	return join(`, `, join(` `, a.First, a.Middle, a.Last), a.Generation())
+}
+
+// CiteAuthors renders a list of authors in a citation style, with the separators and "et al." rules of that style.
+// Set truncated if the list was already cut short, as reported by Authors.
+func CiteAuthors(authors []AuthorStruct, truncated bool, style uint8) string {
+	if len(authors) == 0 {
+		return ``
+	}
+	names := make([]string, 0, len(authors))
+	switch style {
+		case Citation_APA: // up to 20 authors, then the first 19, an ellipsis and the last
+			if len(authors) > 20 {
+				for _, a := range authors[0:19] {
+					names = append(names, a.APA())
+				}
+				return strings.Join(names, `, `) + `, . . . ` + authors[len(authors)-1].APA()
+			}
+			for _, a := range authors {
+				names = append(names, a.APA())
+			}
+			if truncated {
+				return strings.Join(names, `, `) + `, et al.`
+			}
+			if len(names) == 1 {
+				return names[0]
+			}
+			return strings.Join(names[0:len(names)-1], `, `) + `, & ` + names[len(names)-1]
+
+		case Citation_MLA: // one or two authors, otherwise the first and et al.
+			if len(authors) > 2 || truncated {
+				return authors[0].MLA() + `, et al.`
+			}
+			if len(authors) == 1 {
+				return authors[0].MLA()
+			}
+			return authors[0].MLA() + `, and ` + authors[1].notFirst()
+
+		case Citation_Chicago: // up to 10 authors, otherwise the first 7 and et al.
+			if len(authors) > 10 {
+				authors = authors[0:7]
+				truncated = true
+			}
+			names = append(names, authors[0].Chicago())
+			for _, a := range authors[1:] {
+				names = append(names, a.notFirst())
+			}
+			if truncated {
+				return strings.Join(names, `, `) + `, et al.`
+			}
+			if len(names) == 1 {
+				return names[0]
+			}
+			return strings.Join(names[0:len(names)-1], `, `) + `, and ` + names[len(names)-1]
+
+		case Citation_Vancouver: // up to 6 authors, otherwise the first 6 and et al.
+			if len(authors) > 6 {
+				authors = authors[0:6]
+				truncated = true
+			}
+			for _, a := range authors {
+				names = append(names, a.Vancouver())
+			}
+			if truncated {
+				return strings.Join(names, `, `) + `, et al.`
+			}
+			return strings.Join(names, `, `)
+	}
+	for _, a := range authors {
+		names = append(names, a.Display())
+	}
+	return strings.Join(names, `, `)
+}
@@ -0,0 +1,194 @@
+package titlecase
+
+import (
+ "testing"
+)
+
+// Authors with the surnames A, B, C and so on, for testing where the citation styles cut a list short
+func lettered(n int) []AuthorStruct {
+	authors := make([]AuthorStruct, n)
+	for i := range authors {
+		authors[i].Last = string(rune('A' + i))
+	}
+	return authors
+}
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Beethoven, Ludwig van`, `Ludwig van Beethoven`},
+		{`Smith, John A., Jr.`, `John A. Smith Jr.`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Dr. John A. Smith Jr. Ph.D.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Mao Zedong`, `Mao Zedong`},
+		{`Herman "Babe" Ruth`, `Herman "Babe" Ruth`},
+		{`Arthur Wellesley, Duke of Wellington`, `Arthur Wellesley, Duke of Wellington`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Display() != test.out {
+			t.Errorf("%q: Display %q, want %q", test.in, a.Display(), test.out)
+		}
+	}
+}
+
+func TestInverted(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `van Beethoven, Ludwig`},
+		{`Martin Luther King Jr.`, `King, Martin Luther, Jr.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Mao Zedong`, `Mao, Zedong`},
+		{`Tolkien, J. R. R. (John Ronald Reuel)`, `Tolkien, J. R. R. (John Ronald Reuel)`},
+		{`Arthur Wellesley, Duke of Wellington`, `Wellesley, Arthur, Duke of Wellington`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Inverted() != test.out {
+			t.Errorf("%q: Inverted %q, want %q", test.in, a.Inverted(), test.out)
+		}
+	}
+}
+
+func TestAPA(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `van Beethoven, L.`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Smith, J. A., Jr.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Jean-Paul Sartre`, `Sartre, J.-P.`},
+		{`J. R. R. Tolkien`, `Tolkien, J. R. R.`},
+		{`Arthur Wellesley, Duke of Wellington`, `Wellesley, A.`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.APA() != test.out {
+			t.Errorf("%q: APA %q, want %q", test.in, a.APA(), test.out)
+		}
+	}
+}
+
+func TestMLA(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `van Beethoven, Ludwig`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Smith, John A., Jr.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Herman "Babe" Ruth`, `Ruth, Herman`},
+		{`Tolkien, J. R. R. (John Ronald Reuel)`, `Tolkien, J. R. R.`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.MLA() != test.out {
+			t.Errorf("%q: MLA %q, want %q", test.in, a.MLA(), test.out)
+		}
+	}
+}
+
+func TestChicago(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `van Beethoven, Ludwig`},
+		{`Martin Luther King Jr.`, `King, Martin Luther, Jr.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Mao Zedong`, `Mao, Zedong`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Chicago() != test.out {
+			t.Errorf("%q: Chicago %q, want %q", test.in, a.Chicago(), test.out)
+		}
+	}
+}
+
+func TestVancouver(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `van Beethoven L`},
+		{`Dr. John A. Smith Jr., Ph.D.`, `Smith JA Jr`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Jean-Paul Sartre`, `Sartre JP`},
+		{`J. R. R. Tolkien`, `Tolkien JRR`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Vancouver() != test.out {
+			t.Errorf("%q: Vancouver %q, want %q", test.in, a.Vancouver(), test.out)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`Ludwig van Beethoven`, `Beethoven, Ludwig van`},
+		{`Vincent van Gogh`, `Gogh, Vincent van`},
+		{`Martin Luther King Jr.`, `King, Martin Luther, Jr.`},
+		{`World Health Organization`, `World Health Organization`},
+		{`Madonna`, `Madonna`},
+		{`Arthur Wellesley, Duke of Wellington`, `Wellesley, Arthur`},
+	}
+	for _, test := range tests {
+		if _, a := Author(test.in, Language_English); a.Sort() != test.out {
+			t.Errorf("%q: Sort %q, want %q", test.in, a.Sort(), test.out)
+		}
+	}
+}
+
+func TestCiteAuthors(t *testing.T) {
+	tests := []struct {
+		in string
+		style uint8
+		out string
+	}{
+		{`Smith, John A., Jr.`, Citation_APA, `Smith, J. A., Jr.`},
+		{`Smith, John A., Jr. and Mao Zedong`, Citation_APA, `Smith, J. A., Jr., & Mao, Z.`},
+		{`Smith, John A., Jones, Kate, et al.`, Citation_APA, `Smith, J. A., Jones, K., et al.`},
+		{`Madonna and Jean-Paul Sartre`, Citation_APA, `Madonna, & Sartre, J.-P.`},
+		{`Smith, John A., Jr.`, Citation_MLA, `Smith, John A., Jr.`},
+		{`Smith, John A., Jr. and Mao Zedong`, Citation_MLA, `Smith, John A., Jr., and Mao Zedong`},
+		{`Smith, John A.; Jones, Kate; World Health Organization`, Citation_MLA, `Smith, John A., et al.`},
+		{`Smith, John A., Jones, Kate, et al.`, Citation_MLA, `Smith, John A., et al.`},
+		{`Smith, John A.; Jones, Kate; World Health Organization`, Citation_Chicago, `Smith, John A., Kate Jones, and World Health Organization`},
+		{`Smith, John A., Jones, Kate, et al.`, Citation_Chicago, `Smith, John A., Kate Jones, et al.`},
+		{`Madonna and Jean-Paul Sartre`, Citation_Chicago, `Madonna, and Jean-Paul Sartre`},
+		{`Smith, John A., Jr. and Mao Zedong`, Citation_Vancouver, `Smith JA Jr, Mao Z`},
+		{`Smith, John A., Jones, Kate, et al.`, Citation_Vancouver, `Smith JA, Jones K, et al.`},
+	}
+	for _, test := range tests {
+		authors, truncated := Authors(test.in, Language_English)
+		if out := CiteAuthors(authors, truncated, test.style); out != test.out {
+			t.Errorf("%q (style %d) = %q, want %q", test.in, test.style, out, test.out)
+		}
+	}
+	// Each style's et al. rule
+	long := []struct {
+		n int
+		style uint8
+		out string
+	}{
+		{20, Citation_APA, `A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, & T`},
+		{21, Citation_APA, `A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, . . . U`},
+		{2, Citation_MLA, `A, and B`},
+		{3, Citation_MLA, `A, et al.`},
+		{10, Citation_Chicago, `A, B, C, D, E, F, G, H, I, and J`},
+		{11, Citation_Chicago, `A, B, C, D, E, F, G, et al.`},
+		{6, Citation_Vancouver, `A, B, C, D, E, F`},
+		{7, Citation_Vancouver, `A, B, C, D, E, F, et al.`},
+	}
+	for _, test := range long {
+		if out := CiteAuthors(lettered(test.n), false, test.style); out != test.out {
+			t.Errorf("%d authors (style %d) = %q, want %q", test.n, test.style, out, test.out)
+		}
+	}
+	if out := CiteAuthors(nil, false, Citation_APA); out != `` {
+		t.Errorf("no authors = %q, want %q", out, ``)
+	}
+}
@@ -0,0 +1,78 @@
+package titlecase
+
+// Style presets for English titles. Style_Default uses the English small words from the formatter's Dictionaries, the others use their own lists.
+const (
+ Style_Default		= 0
+ Style_Chicago		= 1 // lowercase articles, coordinating conjunctions and all prepositions; lowercase after prefixes in hyphenated compounds
+ Style_APA			= 2 // lowercase articles, conjunctions and prepositions of three letters or fewer
+ Style_MLA			= 3 // lowercase articles, coordinating conjunctions and all prepositions
+ Style_AP			= 4 // lowercase articles, conjunctions and prepositions of three letters or fewer; to is lowercased in infinitives too, as it cannot be told apart from the preposition
+ Style_NYT			= 5 // lowercase the New York Times list of small words; capitalize every part of hyphenated compounds
+ Style_Wikipedia	= 6 // lowercase articles, short coordinating conjunctions and prepositions of four letters or fewer
+)
+
+// How words following a hyphen are capitalized
+const (
+ hyphenSmall	= 0 // the same as any other word
+ hyphenAll		= 1 // always capitalized, including small words
+ hyphenPrefix	= 2 // as hyphenSmall, but kept lowercase after a prefix that cannot stand alone (anti-, pre-, etc.)
+)
+
+type styleStruct struct {
+ small keyList
+ hyphen uint8
+}
+
+var styles [Style_Wikipedia + 1]styleStruct
+
+func init() {
+
+	articles := []string{`a`, `an`, `the`}
+	conjunctions := []string{`and`, `but`, `for`, `nor`, `or`, `so`, `yet`}
+	shortConjunctions := []string{`and`, `as`, `but`, `for`, `if`, `nor`, `or`, `so`, `yet`}
+	// Prepositions that are more often adverbs in titles (up, down, off, out, over) are not included
+	shortPrepositions := []string{`as`, `at`, `by`, `for`, `in`, `of`, `on`, `per`, `to`, `via`}
+	prepositions := []string{`about`, `above`, `across`, `after`, `against`, `along`, `amid`, `among`, `around`, `before`, `behind`, `below`, `beneath`, `beside`,
+	 `besides`, `between`, `beyond`, `concerning`, `despite`, `during`, `except`, `from`, `inside`, `into`, `like`, `near`, `onto`, `outside`, `past`, `regarding`,
+	 `since`, `through`, `throughout`, `till`, `toward`, `towards`, `under`, `underneath`, `until`, `unto`, `upon`, `with`, `within`, `without`}
+
+	join := func(lists ...[]string) []string {
+		var words []string
+		for _, list := range lists {
+			words = append(words, list...)
+		}
+		return words
+	}
+
+	// Chicago capitalizes so and yet
+	styles[Style_Chicago].small.add(join(articles, conjunctions[0:5], shortPrepositions, prepositions, []string{`v`, `vs`}), lowerKey)
+	styles[Style_Chicago].hyphen = hyphenPrefix
+
+	styles[Style_APA].small.add(join(articles, shortConjunctions, shortPrepositions, []string{`off`}), lowerKey)
+
+	styles[Style_MLA].small.add(join(articles, conjunctions, shortPrepositions, prepositions), lowerKey)
+
+	styles[Style_AP].small.add(join(articles, conjunctions, shortPrepositions, []string{`off`}), lowerKey)
+
+	styles[Style_NYT].small.add([]string{`a`, `and`, `as`, `at`, `but`, `by`, `en`, `for`, `if`, `in`, `of`, `on`, `or`, `the`, `to`, `v`, `via`, `vs`}, lowerKey)
+	styles[Style_NYT].hyphen = hyphenAll
+
+	var short []string
+	for _, word := range prepositions {
+		if len(word) <= 4 {
+			short = append(short, word)
+		}
+	}
+	styles[Style_Wikipedia].small.add(join(articles, conjunctions, shortPrepositions, short), lowerKey)
+
+}
+
+// Prefixes that cannot stand alone as words
+func isPrefix(word []rune) bool {
+	switch string(word) {
+		case `anti`, `bi`, `co`, `counter`, `extra`, `hyper`, `infra`, `inter`, `intra`, `macro`, `micro`, `mid`, `mini`, `multi`, `neo`, `non`, `post`, `pre`, `pro`,
+		 `proto`, `pseudo`, `re`, `semi`, `sub`, `trans`, `tri`, `un`:
+			return true
+	}
+	return false
+}
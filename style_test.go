@@ -0,0 +1,142 @@
+package titlecase
+
+import (
+ "testing"
+)
+
+// Titles that show the differences between the style presets
+var styleInputs = []string{
+	`the lord of the rings`,
+	`gone with the wind`,
+	`a river runs through it`,
+	`what we talk about when we talk about love`,
+	`nothing but the truth, so help me`,
+	`the fall and rise of reginald perrin`,
+	`an anti-inflammatory diet for the self-taught cook`,
+	`life after death: an inquiry`,
+	`the case for and against`,
+	`over the top and into the fray`,
+	`sleeping with the enemy`,
+	`war and peace among nations`,
+	`how to train your dragon`,
+}
+
+// The golden output of each style preset, in the order of styleInputs
+var styleGolden = map[uint8][]string{
+	Style_Default: {
+		`The Lord of the Rings`,
+		`Gone With the Wind`,
+		`A River Runs Through It`,
+		`What We Talk About When We Talk About Love`,
+		`Nothing but the Truth, So Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life After Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and Into the Fray`,
+		`Sleeping With the Enemy`,
+		`War and Peace Among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_Chicago: {
+		`The Lord of the Rings`,
+		`Gone with the Wind`,
+		`A River Runs through It`,
+		`What We Talk about When We Talk about Love`,
+		`Nothing but the Truth, So Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-inflammatory Diet for the Self-Taught Cook`,
+		`Life after Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and into the Fray`,
+		`Sleeping with the Enemy`,
+		`War and Peace among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_APA: {
+		`The Lord of the Rings`,
+		`Gone With the Wind`,
+		`A River Runs Through It`,
+		`What We Talk About When We Talk About Love`,
+		`Nothing but the Truth, so Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life After Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and Into the Fray`,
+		`Sleeping With the Enemy`,
+		`War and Peace Among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_MLA: {
+		`The Lord of the Rings`,
+		`Gone with the Wind`,
+		`A River Runs through It`,
+		`What We Talk about When We Talk about Love`,
+		`Nothing but the Truth, so Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life after Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and into the Fray`,
+		`Sleeping with the Enemy`,
+		`War and Peace among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_AP: {
+		`The Lord of the Rings`,
+		`Gone With the Wind`,
+		`A River Runs Through It`,
+		`What We Talk About When We Talk About Love`,
+		`Nothing but the Truth, so Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life After Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and Into the Fray`,
+		`Sleeping With the Enemy`,
+		`War and Peace Among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_NYT: {
+		`The Lord of the Rings`,
+		`Gone With the Wind`,
+		`A River Runs Through It`,
+		`What We Talk About When We Talk About Love`,
+		`Nothing but the Truth, So Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life After Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and Into the Fray`,
+		`Sleeping With the Enemy`,
+		`War and Peace Among Nations`,
+		`How to Train Your Dragon`,
+	},
+	Style_Wikipedia: {
+		`The Lord of the Rings`,
+		`Gone with the Wind`,
+		`A River Runs Through It`,
+		`What We Talk About When We Talk About Love`,
+		`Nothing but the Truth, so Help Me`,
+		`The Fall and Rise of Reginald Perrin`,
+		`An Anti-Inflammatory Diet for the Self-Taught Cook`,
+		`Life After Death: An Inquiry`,
+		`The Case for and Against`,
+		`Over the Top and into the Fray`,
+		`Sleeping with the Enemy`,
+		`War and Peace Among Nations`,
+		`How to Train Your Dragon`,
+	},
+}
+
+func TestStyles(t *testing.T) {
+	for style, golden := range styleGolden {
+		f := New(Options{Language: Language_English, Style: style})
+		for i, in := range styleInputs {
+			if got := f.Format(in); got != golden[i] {
+				t.Errorf("style %d: %q = %q, want %q", style, in, got, golden[i])
+			}
+		}
+	}
+}
@@ -12,6 +12,8 @@ This is a production-quality package made for cleaning and formatting book title
 * Supports common abbreviations (USA, USSR, YMCA, etc.)
 * Supports Roman numerals, without mistaking words for roman numerals
 * Supports hyphenation and slashes
+* Supports URLs, domain names and e-mail addresses
+* Supports decimals, grouped thousands, times and version numbers
 * Repairs grammatical errors in English
 * Redetermines whitespace
 * Converts or strips inappropriate punctuation
@@ -28,7 +30,6 @@ import (
  "bytes"
  "unicode"
  "unicode/utf8"
- "github.com/AlasdairF/BinSearch"
  "github.com/AlasdairF/Custom"
 )
 
@@ -42,84 +43,119 @@ const (
  Language_Portuguese	= 6
 )
 
-type honorStruct struct {
- binsearch.KeyRunes
- format [][]rune
-}
-
-var romanExceptions, makecaps, englishSmall, frenchSmall, germanSmall, italianSmall, spanishSmall, portugueseSmall, titlesabv, titles, multilast binsearch.KeyRunes
-var honor honorStruct
+var defaultDictionaries *Dictionaries
 
 func init() {
 	
+	d := new(Dictionaries)
 	var temp [][]rune
-	var word []rune
 	
 	// Initate exceptions for Roman numerals
 	temp = [][]rune {
 	 []rune("ci"), []rune("cid"), []rune("cill"), []rune("civic"), []rune("civil"), []rune("clim"), []rune("cm"), []rune("di"), []rune("did"), []rune("didi"), []rune("dill"), []rune("dilli"),
-	 []rune("dim"), []rune("divi"), []rune("dividivi"), []rune("dix"), []rune("dixi"), []rune("dixil"), []rune("dm"), []rune("id"), []rune("ill"), []rune("im"), []rune("imid"), []rune("imidic"),
+	 []rune("dim"), []rune("divi"), []rune("dividivi"), []rune("dix"), []rune("dixi"), []rune("dixil"), []rune("dm"), []rune("id"), []rune("il"), []rune("ill"), []rune("im"), []rune("imid"), []rune("imidic"),
 	 []rune("immix"), []rune("ld"), []rune("li"), []rune("lid"), []rune("lil"), []rune("lili"), []rune("lill"), []rune("lilli"), []rune("lim"), []rune("liv"), []rune("livi"), []rune("livid"),
 	 []rune("livvi"), []rune("lm"), []rune("lviv"), []rune("mic"), []rune("mid"), []rune("midi"), []rune("mil"), []rune("mild"), []rune("mill"), []rune("milli"), []rune("mim"),
 	 []rune("mimi"), []rune("mimic"), []rune("mix"), []rune("mv"), []rune("vi"), []rune("vic"), []rune("vici"), []rune("vid"), []rune("vild"), []rune("vill"), []rune("villi"), []rune("vim"),
 	 []rune("viv"), []rune("vivi"), []rune("vivid"), []rune("vivl"), //[]rune("md"),
 	}
-	for _, word = range temp {
-		romanExceptions.AddUnsorted(word)
-	}
-	romanExceptions.Build()
-	romanExceptions.Optimize()
+	d.romanExceptions.words = temp
+	d.romanExceptions.build()
 	
 	// Initate exceptions for ALLCAPS
 	temp = [][]rune {
 	 []rune("abc"), []rune("usa"), []rune("ussr"), []rune("usaf"), []rune("uscg"), []rune("usmc"), []rune("usn"), []rune("ymca"), []rune("raf"), []rune("uk"),
+	 []rune("nasa"), []rune("unesco"), []rune("unicef"), []rune("fbi"), []rune("bbc"), []rune("ibm"), []rune("isbn"), []rune("dna"),
 	}
-	for _, word = range temp {
-		makecaps.AddUnsorted(word)
-	}
-	makecaps.Build()
-	makecaps.Optimize()
+	d.makecaps.words = temp
+	d.makecaps.build()
 	
 	// Initate exceptions for titlesabv
 	temp = [][]rune {
-	 []rune("mr"), []rune("ms"), []rune("miss"), []rune("mrs"), []rune("dr"), []rune("prof"), []rune("rev"), []rune("esq"), []rune("hon"), []rune("jr"), []rune("messrs"), []rune("mmes"), []rune("msgr"), []rune("rt"),
-	 []rune("sr"), []rune("st"), []rune("lt"), []rune("col"), []rune("gen"), []rune("pseud"), []rune("maj"), []rune("brig"), []rune("capt"), []rune("sgt"), []rune("cpl"), []rune("pvt"), []rune("pfc"), []rune("cmdr"),
+	 []rune("mr"), []rune("ms"), []rune("miss"), []rune("mrs"), []rune("dr"), []rune("prof"), []rune("rev"), []rune("esq"), []rune("hon"), []rune("messrs"), []rune("mmes"), []rune("msgr"), []rune("rt"),
+	 []rune("sr"), []rune("st"), []rune("lt"), []rune("col"), []rune("gen"), []rune("maj"), []rune("brig"), []rune("capt"), []rune("sgt"), []rune("cpl"), []rune("pvt"), []rune("pfc"), []rune("cmdr"),
 	 []rune("adm"), []rune("lieut"), []rune("pte"),
 	}
-	for _, word = range temp {
-		titlesabv.AddUnsorted(word)
-	}
-	titlesabv.Build()
-	titlesabv.Optimize()
+	d.titlesabv.words = temp
+	d.titlesabv.build()
 	
 	// Initate exceptions for titles
 	temp = [][]rune {
-	 []rune("Sir"), []rune("Lord"), []rune("Baron"), []rune("Count"), []rune("Viscount"), []rune("Duke"), []rune("Marquess"), []rune("Earl"), []rune("Laird"), []rune("Master"), []rune("Bishop"), []rune("Father"), []rune("Sister"),
-	 []rune("Pope"), []rune("Rabbi"), []rune("General"), []rune("Major"), []rune("Private"), []rune("Captain"), []rune("Sergent"), []rune("Commander"), []rune("Admiral"), []rune("Lieutenant"), []rune("Marquise"), []rune("Duca"),
-	 []rune("Abbot"), []rune("Reverend"), []rune("Deacon"), []rune("Archbishop"), []rune("Cardinal"), []rune("Chancellor"), []rune("Chaplain"), []rune("Vicar"), []rune("Doctor"), []rune("Guru"), []rune("principe"), []rune("marchese"),
-	 []rune("Prince"), []rune("King"), []rune("Queen"), []rune("Princess"), []rune("Emperor"), []rune("Caesar"), []rune("tsar"), []rune("Czar"), []rune("Csar"), []rune("Tzar"), []rune("Kaiser"), []rune("Sultan"), []rune("Conte"),
-	 []rune("Dauphin"), []rune("Infante"), []rune("Margrave"), []rune("Marquis"), []rune("Freiherr"), []rune("Seigneur"), []rune("Nobile"), []rune("Baronet"), []rune("Dominus"), []rune("Vidame"), []rune("Vavasour"), []rune("Contessa"),
-	 []rune("Vidame"), []rune("Kurfürst"), []rune("Prinz"), []rune("Viceroy"), []rune("Markgraf"), []rune("Graf"), []rune("Vizegraf"), []rune("Compte"), []rune("Comptesse"), []rune("Báró"), []rune("Barón"), []rune("Barone"),
-	 []rune("Chevalier"), []rune("Ritter"), []rune("Cavaliere"), []rune("Nobiluomo"), []rune("Duque"), []rune("Príncipe"), []rune("Marquês"), []rune("Conde"), []rune("Visconde"), []rune("Barão"), []rune("Baronete"), []rune("Professor"),
-	 []rune("Duchess"), []rune("Countess"), []rune("Baroness"), []rune("Dame"), []rune("Duc"), []rune("Viceroi"), []rune("Fürst"), []rune("Baronetto"), []rune("Principessa"), []rune("Visconte"), []rune("Princesse"), []rune("Roi"),
-	 []rune("Reine"), []rune("Kaiserin"), []rune("König"), []rune("Königin"), []rune("Re"), []rune("Regina"), []rune("Rei"), []rune("Rainha"), []rune("Pape"), []rune("Papa"), []rune("Papst"), []rune("Monsieur"), []rune("Madame"),
-	 []rune("Herr"), []rune("Père"), []rune("Padre"), []rune("Vater"), []rune("Saint"), []rune("Heilige"), []rune("San"), []rune("Arciduca"), []rune("Commodore"), []rune("Regent"), []rune("Lady"),
-	}
-	for _, word = range temp {
-		titles.AddUnsorted(word)
+	 []rune("sir"), []rune("lord"), []rune("baron"), []rune("count"), []rune("viscount"), []rune("duke"), []rune("marquess"), []rune("earl"), []rune("laird"), []rune("master"), []rune("bishop"), []rune("father"), []rune("sister"),
+	 []rune("pope"), []rune("rabbi"), []rune("general"), []rune("major"), []rune("private"), []rune("captain"), []rune("sergent"), []rune("commander"), []rune("admiral"), []rune("lieutenant"), []rune("marquise"), []rune("duca"),
+	 []rune("abbot"), []rune("reverend"), []rune("deacon"), []rune("archbishop"), []rune("cardinal"), []rune("chancellor"), []rune("chaplain"), []rune("vicar"), []rune("doctor"), []rune("guru"), []rune("principe"), []rune("marchese"),
+	 []rune("prince"), []rune("king"), []rune("queen"), []rune("princess"), []rune("emperor"), []rune("caesar"), []rune("tsar"), []rune("czar"), []rune("csar"), []rune("tzar"), []rune("kaiser"), []rune("sultan"), []rune("conte"),
+	 []rune("dauphin"), []rune("infante"), []rune("margrave"), []rune("marquis"), []rune("freiherr"), []rune("seigneur"), []rune("nobile"), []rune("baronet"), []rune("dominus"), []rune("vidame"), []rune("vavasour"), []rune("contessa"),
+	 []rune("kurfürst"), []rune("prinz"), []rune("viceroy"), []rune("markgraf"), []rune("graf"), []rune("vizegraf"), []rune("compte"), []rune("comptesse"), []rune("báró"), []rune("barón"), []rune("barone"),
+	 []rune("chevalier"), []rune("ritter"), []rune("cavaliere"), []rune("nobiluomo"), []rune("duque"), []rune("príncipe"), []rune("marquês"), []rune("conde"), []rune("visconde"), []rune("barão"), []rune("baronete"), []rune("professor"),
+	 []rune("duchess"), []rune("countess"), []rune("baroness"), []rune("dame"), []rune("duc"), []rune("viceroi"), []rune("fürst"), []rune("baronetto"), []rune("principessa"), []rune("visconte"), []rune("princesse"), []rune("roi"),
+	 []rune("reine"), []rune("kaiserin"), []rune("könig"), []rune("königin"), []rune("re"), []rune("regina"), []rune("rei"), []rune("rainha"), []rune("pape"), []rune("papa"), []rune("papst"), []rune("monsieur"), []rune("madame"),
+	 []rune("herr"), []rune("père"), []rune("padre"), []rune("vater"), []rune("saint"), []rune("heilige"), []rune("san"), []rune("arciduca"), []rune("commodore"), []rune("regent"), []rune("lady"),
+	 []rune("brother"), []rune("mother"), []rune("honourable"), []rune("honorable"), []rune("sheikh"), []rune("imam"), []rune("emir"), []rune("shah"), []rune("maharaja"),
+	 // Titles of more than one word
+	 []rune("his majesty"), []rune("her majesty"), []rune("his royal highness"), []rune("her royal highness"), []rune("his highness"), []rune("her highness"), []rune("his excellency"),
+	 []rune("her excellency"), []rune("his holiness"), []rune("his eminence"), []rune("his grace"), []rune("her grace"), []rune("right honourable"), []rune("right honorable"),
+	 []rune("sister mary"), []rune("mother superior"), []rune("sa majesté"), []rune("seine majestät"), []rune("ihre majestät"), []rune("sua maestà"),
+	 []rune("su majestad"), []rune("sua majestade"),
 	}
-	titles.Build()
-	titles.Optimize()
+	d.titles.words = temp
+	d.titles.build()
 	
 	// Initate exceptions for mutli-part last names
 	temp = [][]rune {
-	 []rune("de"), []rune("da"), []rune("di"), []rune("von"), []rune("van"), []rune("le"), []rune("la"), []rune("du"), []rune("des"), []rune("del"), []rune("della"), []rune("der"),
+	 []rune("de"), []rune("da"), []rune("di"), []rune("von"), []rune("van"), []rune("le"), []rune("la"), []rune("du"), []rune("des"), []rune("del"), []rune("della"), []rune("der"), []rune("den"),
+	 []rune("ten"), []rune("ter"), []rune("te"), []rune("op"), []rune("zu"), []rune("zum"), []rune("zur"), []rune("vom"), []rune("dos"), []rune("das"), []rune("do"), []rune("los"), []rune("las"),
+	 []rune("degli"), []rune("dei"), []rune("dal"), []rune("dalla"), []rune("delle"), []rune("dello"), []rune("af"),
 	}
-	for _, word = range temp {
-		multilast.AddUnsorted(word)
+	d.multilast.words = temp
+	d.multilast.build()
+	
+	// Initate words that identify corporate authors
+	temp = [][]rune {
+	 []rune("society"), []rune("university"), []rune("department"), []rune("dept"), []rune("ministry"), []rune("association"), []rune("press"), []rune("company"), []rune("co"), []rune("inc"), []rune("ltd"),
+	 []rune("llc"), []rune("plc"), []rune("corp"), []rune("corporation"), []rune("incorporated"), []rune("limited"), []rune("institute"), []rune("foundation"), []rune("council"), []rune("committee"),
+	 []rune("commission"), []rune("bureau"), []rune("agency"), []rune("library"), []rune("museum"), []rune("academy"), []rune("organization"), []rune("organisation"), []rune("federation"),
+	 []rune("publishers"), []rune("publishing"), []rune("bank"), []rune("gmbh"), []rune("ag"), []rune("kg"), []rune("s.a"), []rune("s.l"), []rune("s.p.a"), []rune("s.r.l"), []rune("sa"), []rune("spa"), []rune("srl"),
+	 []rune("universität"), []rune("gesellschaft"), []rune("verein"), []rune("verlag"), []rune("ministerium"), []rune("stiftung"), []rune("bibliothek"), []rune("akademie"), []rune("institut"),
+	 []rune("université"), []rune("société"), []rune("ministère"), []rune("bibliothèque"), []rune("académie"), []rune("fondation"), []rune("éditions"), []rune("musée"),
+	 []rune("università"), []rune("società"), []rune("ministero"), []rune("biblioteca"), []rune("accademia"), []rune("istituto"), []rune("fondazione"), []rune("associazione"), []rune("editrice"),
+	 []rune("universidad"), []rune("sociedad"), []rune("ministerio"), []rune("academia"), []rune("instituto"), []rune("fundación"), []rune("asociación"), []rune("editorial"), []rune("museo"), []rune("banco"),
+	 []rune("universidade"), []rune("sociedade"), []rune("fundação"), []rune("associação"), []rune("editora"),
+	}
+	d.corporate.words = temp
+	d.corporate.build()
+	
+	// Initate family names that are written first, leaving out those that are also Western names or words (Lee, Abe, Han, Moon, etc.), which are handled in isFamilyFirst
+	temp = [][]rune {
+	 // Chinese
+	 []rune("wang"), []rune("li"), []rune("zhang"), []rune("liu"), []rune("chen"), []rune("yang"), []rune("huang"), []rune("zhao"), []rune("wu"), []rune("zhou"), []rune("xu"),
+	 []rune("zhu"), []rune("hu"), []rune("guo"), []rune("gao"), []rune("luo"), []rune("zheng"), []rune("liang"), []rune("xie"), []rune("tang"), []rune("feng"), []rune("deng"),
+	 []rune("cao"), []rune("peng"), []rune("zeng"), []rune("xiao"), []rune("tian"), []rune("dong"), []rune("yuan"), []rune("cai"), []rune("jiang"), []rune("yu"),
+	 []rune("cheng"), []rune("wei"), []rune("ding"), []rune("ren"), []rune("shen"), []rune("yao"), []rune("jin"), []rune("fu"), []rune("zhong"), []rune("cui"),
+	 []rune("mao"), []rune("hou"), []rune("shao"), []rune("meng"), []rune("qian"), []rune("kong"), []rune("bai"), []rune("xi"), []rune("qin"), []rune("zhuang"), []rune("cheung"),
+	 []rune("wong"), []rune("chow"), []rune("leung"), []rune("chan"), []rune("lau"), []rune("lam"), []rune("ng"), []rune("tsang"), []rune("kwok"),
+	 // Japanese
+	 []rune("sato"), []rune("suzuki"), []rune("takahashi"), []rune("tanaka"), []rune("watanabe"), []rune("ito"), []rune("yamamoto"), []rune("nakamura"), []rune("kobayashi"), []rune("kato"),
+	 []rune("yoshida"), []rune("yamada"), []rune("sasaki"), []rune("yamaguchi"), []rune("matsumoto"), []rune("inoue"), []rune("kimura"), []rune("hayashi"), []rune("shimizu"), []rune("yamazaki"),
+	 []rune("mori"), []rune("ikeda"), []rune("hashimoto"), []rune("yamashita"), []rune("ishikawa"), []rune("nakajima"), []rune("maeda"), []rune("fujita"), []rune("ogawa"), []rune("goto"),
+	 []rune("okada"), []rune("hasegawa"), []rune("murakami"), []rune("kondo"), []rune("saito"), []rune("sakamoto"), []rune("endo"), []rune("aoki"), []rune("nishimura"), []rune("fukuda"),
+	 []rune("fujiwara"), []rune("okamoto"), []rune("matsuda"), []rune("nakagawa"), []rune("natsume"), []rune("kurosawa"), []rune("mishima"), []rune("kawabata"), []rune("akutagawa"), []rune("miyazaki"),
+	 []rune("tokugawa"), []rune("oda"), []rune("toyotomi"), []rune("minamoto"), []rune("taira"),
+	 // Korean
+	 []rune("park"), []rune("choi"), []rune("kang"), []rune("cho"), []rune("yoon"), []rune("jang"), []rune("lim"), []rune("seo"), []rune("shin"), []rune("kwon"),
+	 []rune("hwang"), []rune("ahn"), []rune("yoo"), []rune("hong"), []rune("jeon"), []rune("baek"), []rune("heo"), []rune("pak"), []rune("yi"),
+	 // Vietnamese
+	 []rune("nguyen"), []rune("nguyễn"), []rune("tran"), []rune("trần"), []rune("pham"), []rune("phạm"), []rune("hoang"), []rune("hoàng"), []rune("huynh"), []rune("huỳnh"), []rune("phan"),
+	 []rune("vu"), []rune("vũ"), []rune("vo"), []rune("võ"), []rune("dang"), []rune("đặng"), []rune("bui"), []rune("bùi"), []rune("ngo"), []rune("ngô"), []rune("duong"), []rune("dương"), []rune("lê"), []rune("đỗ"), []rune("hồ"),
+	 // Hungarian
+	 []rune("nagy"), []rune("kovács"), []rune("tóth"), []rune("szabó"), []rune("horváth"), []rune("varga"), []rune("molnár"), []rune("németh"), []rune("farkas"), []rune("balogh"),
+	 []rune("papp"), []rune("takács"), []rune("juhász"), []rune("lakatos"), []rune("mészáros"), []rune("oláh"), []rune("rácz"), []rune("fekete"), []rune("szilágyi"), []rune("török"), []rune("fehér"),
+	 []rune("balázs"), []rune("gál"), []rune("szűcs"), []rune("kocsis"), []rune("fodor"), []rune("szalai"), []rune("sipos"), []rune("lukács"), []rune("gulyás"), []rune("bíró"), []rune("király"),
+	 []rune("katona"), []rune("fazekas"), []rune("kelemen"), []rune("somogyi"), []rune("hegedűs"), []rune("bartók"), []rune("kodály"), []rune("liszt"), []rune("petőfi"), []rune("arany"), []rune("jókai"),
+	 []rune("kertész"), []rune("esterházy"), []rune("bánffy"), []rune("márai"), []rune("karinthy"), []rune("móricz"), []rune("széchenyi"), []rune("kossuth"),
 	}
-	multilast.Build()
-	multilast.Optimize()
+	d.familyFirst.words = temp
+	d.familyFirst.build()
 	
 	// Initate exceptions for honor
 	temp = [][]rune {
@@ -136,11 +172,10 @@ func init() {
 	 []rune("m.s.m.sci"), []rune("m.s.mt.e"), []rune("m.s.n"), []rune("m.s.o.r"), []rune("m.s.o.t"), []rune("m.s.p.a.s"), []rune("m.s.p.h"), []rune("m.s.s.e"), []rune("m.s.w"), []rune("m.sw.e"), []rune("m.t.a"), []rune("m.tx"),
 	 []rune("m.u.r.p"), []rune("ed.s"), []rune("au.d"), []rune("d.b.a"), []rune("d.m.a"), []rune("d.m.d"), []rune("d.n.p"), []rune("d.p.t"), []rune("dr.p.h"), []rune("d.sc"), []rune("d.v.m"), []rune("ed.d"), []rune("j.d"),
 	 []rune("m.d"), []rune("o.d"), []rune("pharm.d"), []rune("ph.d"), []rune("e.g"), []rune("i.e"), []rune("lt.col"), []rune("d.d"),
+	 []rune("b.c"), []rune("a.d"), []rune("c.e"), []rune("s.a"), []rune("s.l"), []rune("s.p.a"), []rune("s.r.l"),
 	}
-	for _, word = range temp {
-		honor.AddUnsorted(word)
-	}
-	honor.format = [][]rune {
+	d.honor.words = temp
+	d.honor.display = [][]rune {
 	 []rune("A.A"), []rune("A.A.S"), []rune("A.A.T"), []rune("A.O.T"), []rune("A.S"), []rune("B.A"), []rune("B.A.B.A"), []rune("B.A.Com"), []rune("B.A.E"), []rune("B.A.Ed"), []rune("B.Arch"), []rune("B.A.S"), []rune("B.B.A"), 
 	 []rune("B.B.E"), []rune("B.C.E"), []rune("B.Che.E"), []rune("B.E.E"), []rune("B.F.A"), []rune("B.G.S"), []rune("B.I.Arch"), []rune("B.In.Dsn"), []rune("B.I.S"), []rune("B.I.S.E"), []rune("B.L.A"), []rune("B.M"), []rune("B.M.E"),
 	 []rune("B.M.Ed"), []rune("B.Mtl.E"), []rune("B.P.F.E"), []rune("B.P.H.S"), []rune("B.S"), []rune("B.S.A.E"), []rune("B.S.B.A"), []rune("B.S.B.M.E"), []rune("B.S.C.B.A"), []rune("B.S.C.E"), []rune("B.S.Che.E"), []rune("B.S.Chem"),
@@ -154,79 +189,69 @@ func init() {
 	 []rune("M.S.M.Sci"), []rune("M.S.Mt.E"), []rune("M.S.N"), []rune("M.S.O.R"), []rune("M.S.O.T"), []rune("M.S.P.A.S"), []rune("M.S.P.H"), []rune("M.S.S.E"), []rune("M.S.W"), []rune("M.Sw.E"), []rune("M.T.A"), []rune("M.Tx"),
 	 []rune("M.U.R.P"), []rune("Ed.S"), []rune("Au.D"), []rune("D.B.A"), []rune("D.M.A"), []rune("D.M.D"), []rune("D.N.P"), []rune("D.P.T"), []rune("Dr.P.H"), []rune("D.Sc"), []rune("D.V.M"), []rune("Ed.D"), []rune("J.D"),
 	 []rune("M.D"), []rune("O.D"), []rune("Pharm.D"), []rune("Ph.D"), []rune("E.g"), []rune("I.e"), []rune("Lt.Col"), []rune("D.D"),
+	 []rune("B.C"), []rune("A.D"), []rune("C.E"), []rune("S.A"), []rune("S.L"), []rune("S.p.A"), []rune("S.r.l"),
+	}
+	d.honor.build()
+	
+	// Initiate display forms for words that have their own casing, which apply in every language so must not also be words, e.g. NATO is Italian for born
+	d.display.display = [][]rune {
+	 []rune("iPhone"), []rune("iPad"), []rune("iPod"), []rune("iTunes"), []rune("iMac"), []rune("eBay"), []rune("YouTube"), []rune("PayPal"), []rune("LinkedIn"), []rune("GitHub"),
+	 []rune("JavaScript"), []rune("TypeScript"), []rune("PowerPoint"), []rune("PlayStation"), []rune("McGraw"), []rune("DeVries"), []rune("MacArthur"), []rune("DiCaprio"),
+	 []rune("GmbH"),
 	}
-	temp = make([][]rune, len(honor.format))
-	newindexes, _ := honor.Build()
-	for indx_new, indx_old := range newindexes {
-		temp[indx_new] = honor.format[indx_old]
+	for _, word := range d.display.display {
+		d.display.words = append(d.display.words, lowerKey(string(word)))
 	}
-	honor.format = temp
-	honor.Optimize()
+	d.display.build()
 	
 	// Initiate exceptions for English small words
 	temp = [][]rune {
 	 []rune("a"), []rune("an"), []rune("and"), []rune("as"), []rune("at"), []rune("but"), []rune("by"), []rune("for"), []rune("if"), []rune("in"), []rune("of"), []rune("on"), []rune("or"), []rune("the"), []rune("to"),
 	}
-	for _, word = range temp {
-		englishSmall.AddUnsorted(word)
-	}
-	englishSmall.Build()
-	englishSmall.Optimize()
+	d.small[Language_English].words = temp
+	d.small[Language_English].build()
 	
 	// Initiate exceptions for French small words: d', l'
 	temp = [][]rune {
 	 []rune("à"), []rune("au"), []rune("aux"), []rune("ce"), []rune("cette"), []rune("dans"), []rune("de"), []rune("des"), []rune("du"), []rune("en"), []rune("la"), []rune("le"), []rune("les"), []rune("ou"), []rune("par"),
 	 []rune("pour"), []rune("sur"), []rune("un"),[]rune("une"),
 	}
-	for _, word = range temp {
-		frenchSmall.AddUnsorted(word)
-	}
-	frenchSmall.Build()
-	frenchSmall.Optimize()
+	d.small[Language_French].words = temp
+	d.small[Language_French].build()
 	
 	// Initiate exceptions for German small words
 	temp = [][]rune {
 	 []rune("als"), []rune("am"), []rune("an"), []rune("auf"), []rune("aus"), []rune("bei"), []rune("bis"), []rune("das"), []rune("dem"), []rune("den"), []rune("der"), []rune("des"), []rune("die"), []rune("ein"), []rune("eine"),
 	 []rune("für"), []rune("im"), []rune("in"), []rune("ins"), []rune("mit"), []rune("nach"), []rune("oder"), []rune("og"), []rune("und"), []rune("van"), []rune("vom"), []rune("von"), []rune("wie"), []rune("zu"), []rune("zum"), []rune("zur"),
 	}
-	for _, word = range temp {
-		germanSmall.AddUnsorted(word)
-	}
-	germanSmall.Build()
-	germanSmall.Optimize()
+	d.small[Language_German].words = temp
+	d.small[Language_German].build()
 	
 	// Initiate exceptions for Italian small words
 	temp = [][]rune {
 	 []rune("a"), []rune("al"), []rune("con"), []rune("da"), []rune("dai"), []rune("dal"), []rune("dei"), []rune("del"), []rune("della"), []rune("di"), []rune("e"), []rune("ed"), []rune("i"), []rune("il"), []rune("in"),
 	 []rune("la"), []rune("le"), []rune("lo"), []rune("nella"), []rune("o"), []rune("per"), []rune("se"), []rune("su"), []rune("un"), []rune("una"), []rune("uno"),
 	}
-	for _, word = range temp {
-		italianSmall.AddUnsorted(word)
-	}
-	italianSmall.Build()
-	italianSmall.Optimize()
+	d.small[Language_Italian].words = temp
+	d.small[Language_Italian].build()
 	
 	// Initiate exceptions for Portuguese small words
 	temp = [][]rune {
 	 []rune("à"), []rune("às"), []rune("ao"), []rune("da"), []rune("das"), []rune("de"), []rune("do"), []rune("e"), []rune("em"), []rune("na"), []rune("no"), []rune("o"), []rune("para"), []rune("pelo"), []rune("pelos"),
 	 []rune("por"), []rune("se"), []rune("um"), []rune("uma"), []rune("pelas"), []rune("pela"),
 	}
-	for _, word = range temp {
-		portugueseSmall.AddUnsorted(word)
-	}
-	portugueseSmall.Build()
-	portugueseSmall.Optimize()
+	d.small[Language_Portuguese].words = temp
+	d.small[Language_Portuguese].build()
 	
 	// Initiate exceptions for Spanish small words
 	temp = [][]rune {
 	 []rune("a"), []rune("al"), []rune("de"), []rune("del"), []rune("e"), []rune("é"), []rune("el"), []rune("en"), []rune("la"), []rune("las"), []rune("los"), []rune("o"), []rune("ó"), []rune("para"), []rune("por"),
 	 []rune("si"), []rune("un"), []rune("una"), []rune("y"),
 	}
-	for _, word = range temp {
-		spanishSmall.AddUnsorted(word)
-	}
-	spanishSmall.Build()
-	spanishSmall.Optimize()
+	d.small[Language_Spanish].words = temp
+	d.small[Language_Spanish].build()
+	
+	defaultDictionaries = d
 	
 }
 
@@ -245,12 +270,15 @@ func equal(a, b []rune) bool {
 // Structs
 type wordStruct struct {
  content []rune
+ original []rune // as it was typed
  isStart bool
  isEnd bool
  isHonor bool
  isTitle bool
  isRoman bool
- contraction uint8
+ isAtomic bool // URL, domain name or e-mail address
+ part uint8 // for authors, which part of the author string this word is in
+ contraction int
  spaceAfter uint8 // 0=nothing, 1=space, 2=hypen, 3=slash, 4=end
  puncBefore []rune
  puncAfter []rune
@@ -262,27 +290,45 @@ type AuthorStruct struct {
  Middle string
  Title string
  Suffix string
+ Born string
+ Died string
+ Dates string // life dates as written, e.g. 1812-1870, b. 1564, fl. 8th cent. B.C.
+ FullerForm string // the given names in full, when they are also written as initials, e.g. John Ronald Reuel
+ Nickname string // e.g. Babe, from Herman "Babe" Ruth
+ IsPseudonym bool // the name is marked as a pseudonym, e.g. Twain, Mark, pseud.
+ RealName *AuthorStruct // the real name of a pseudonym, if given, e.g. Twain, Mark, pseudonym of Samuel Langhorne Clemens
+ Designation string // a territorial designation or peerage, e.g. Duke of Wellington or Lord Byron
+ Role string // editor, translator, compiler or illustrator, from relator terms such as ed., trans., Hrsg. and a cura di
+ FamilyFirst bool // the family name is written before the given names, e.g. Mao Zedong
+ Corporate string // the whole name, if the author is a corporate body such as a society, university or company, in which case the name is not split
+ language uint8 // the language the name was read in, which decides the main surname for MatchKey
 }
 
 type runebuf struct {
  runes []rune
  len int
+ dict *Dictionaries
+ atomic bool // the word is a URL, domain name or e-mail address
 }
 func (r *runebuf) write(rn rune) {
-	r.runes[r.len] = rn
+	if r.len == len(r.runes) {
+		r.runes = append(r.runes, rn)
+	} else {
+		r.runes[r.len] = rn
+	}
 	r.len++
 }
-func newRuneBuf() *runebuf {
+func newRuneBuf(dict *Dictionaries) *runebuf {
 	r := new(runebuf)
 	r.runes = make([]rune, 256)
+	r.dict = dict
 	return r
 }
 func (r *runebuf) add(words []wordStruct, spaceType uint8) []wordStruct {
 	l := r.len
 	w := r.runes[0:l]
 	puncBefore := make([]rune, 0)
-	var i, i2, i3 int
-	var i4 uint8
+	var i, i2, i3, i4 int
 	// Get punctuation before word
 	for i=0; i<l; i++ {
 		if unicode.IsPunct(w[i]) {
@@ -306,32 +352,47 @@ func (r *runebuf) add(words []wordStruct, spaceType uint8) []wordStruct {
 	// Get word
 	var rn rune
 	var isHonor bool
-	var contraction uint8
+	var contraction int
 	i4 = 0
 	content := make([]rune, i2 - i)
-	noise := make([]uint8, 0)
+	original := make([]rune, i2 - i)
+	copy(original, w[i:i2])
+	noise := make([]int, 0)
 	for i3=i; i3<i2; i3++ {
 		rn = w[i3]
 		switch rn {
 			case '.', ',', ';', ':', '!', '?', '&': // if any of these occur in the middle of a word (surrounded by letters) then split into two words
 				noise = append(noise, i4 + 1)
 			case 39, '’':
-				if int(i4) < (i2 - i) - 2 {
+				if i4 < (i2 - i) - 2 {
 					contraction = i4
 				}
 		}
-		content[i4] = unicode.ToLower(w[i3])
+		if r.atomic {
+			content[i4] = w[i3]
+		} else {
+			content[i4] = unicode.ToLower(w[i3])
+		}
 		i4++
 	}
+	isAtomic := r.atomic
+	if isAtomic {
+		lowerAtomic(content)
+		contraction = 0
+		noise = noise[0:0]
+	} else if len(noise) > 0 && isNumeric(content) { // numbers, times and versions are kept together
+		isAtomic = true
+		noise = noise[0:0]
+	}
 	// Check if any noise occurred
 	if len(noise) > 0 {
-		if id, ok := honor.Find(content); ok { // if it's an honor then save the way it should be displayed
+		if id, ok := r.dict.honor.Find(content); ok { // if it's an honor then save the way it should be displayed
 			isHonor = true
-			content = honor.format[id]
+			content = r.dict.honor.format[id]
 		} else { // if it's not then split the word
 			backup := r.runes
-			saver := make([]rune, len(content))
-			copy(saver, content)
+			saver := make([]rune, len(original))
+			copy(saver, original)
 			r.runes = puncBefore
 			r.runes = append(r.runes, saver[0:noise[0]]...)
 			r.len = len(r.runes)
@@ -358,11 +419,38 @@ func (r *runebuf) add(words []wordStruct, spaceType uint8) []wordStruct {
 	}
 	// Reset buffer
 	r.len = 0
-	words = append(words, wordStruct{content, false, isEnd, isHonor, false, false, contraction, spaceType, puncBefore, puncAfter})
+	r.atomic = false
+	words = append(words, wordStruct{content: content, original: original, isEnd: isEnd, isHonor: isHonor, isAtomic: isAtomic, contraction: contraction, spaceAfter: spaceType, puncBefore: puncBefore, puncAfter: puncAfter})
 	return words
 }
 
-func isRoman(word []rune) bool {
+// Whether the word is an abbreviation followed by a period, such as Dept., Mr., Ph.D. or an initial, so the period does not end a sentence
+func (d *Dictionaries) isAbbreviation(ws *wordStruct) bool {
+	if len(ws.puncAfter) == 0 || ws.puncAfter[0] != '.' || len(ws.content) == 0 {
+		return false
+	}
+	if ws.isHonor || len(ws.content) == 1 {
+		return true
+	}
+	if _, ok := d.titlesabv.Find(ws.content); ok {
+		return true
+	}
+	_, ok := d.corporate.Find(ws.content)
+	return ok
+}
+
+// Whether the punctuation after a word ends a sentence or clause, as opposed to closing brackets or quotes
+func endsSentence(punc []rune) bool {
+	for _, r := range punc {
+		switch r {
+			case '.', '!', '?', ':', ';':
+				return true
+		}
+	}
+	return false
+}
+
+func (d *Dictionaries) isRoman(word []rune) bool {
 	var r rune
 	for _, r = range word {
 		switch r {
@@ -372,7 +460,7 @@ func isRoman(word []rune) bool {
 				return false
 		}
 	}
-	if _, ok := romanExceptions.Find(word); ok {
+	if _, ok := d.romanExceptions.Find(word); ok {
 		return false
 	} else {
 		return true
@@ -439,6 +527,45 @@ func upperRune(word []rune, which int) {
 	word[which] = unicode.ToTitle(word[which])
 }
 
+// Whether str contains both uppercase and lowercase letters
+func isMixedCase(str string) bool {
+	var upper, lower bool
+	for _, r := range str {
+		if unicode.IsUpper(r) {
+			upper = true
+		} else if unicode.IsLower(r) {
+			lower = true
+		}
+		if upper && lower {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether every word after the first begins with a capital, so the capitals in the input do not show which words are names, e.g. Travels In London And Paris
+func isTitleCased(words []wordStruct) bool {
+	for i := 1; i < len(words); i++ {
+		if len(words[i].original) > 0 && unicode.IsLower(words[i].original[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Whether a word has a capital letter after its first letter and also contains lowercase letters, e.g. iPhone, McGraw, LaTeX
+func hasInternalCapital(word []rune) bool {
+	var upper, lower bool
+	for i, r := range word {
+		if unicode.IsLower(r) {
+			lower = true
+		} else if i > 0 && unicode.IsUpper(r) {
+			upper = true
+		}
+	}
+	return upper && lower
+}
+
 // Removes 2 individual bytes from a slice of bytes
 func removeBytes(s []byte, a byte, b byte) []byte {
 	var on int
@@ -463,57 +590,71 @@ func replaceRune(s []rune, from rune, to rune) {
 */
 
 func English(str string) string {
-	str, _ = format(str, Language_English, false)
+	str, _ = newFormatter(Language_English).format(str, false)
 	return str
 }
 
 func French(str string) string {
-	str, _ = format(str, Language_French, false)
+	str, _ = newFormatter(Language_French).format(str, false)
 	return str
 }
 
 func German(str string) string {
-	str, _ = format(str, Language_German, false)
+	str, _ = newFormatter(Language_German).format(str, false)
 	return str
 }
 
 func Italian(str string) string {
-	str, _ = format(str, Language_Italian, false)
+	str, _ = newFormatter(Language_Italian).format(str, false)
 	return str
 }
 
 func Spanish(str string) string {
-	str, _ = format(str, Language_Spanish, false)
+	str, _ = newFormatter(Language_Spanish).format(str, false)
 	return str
 }
 
 func Portuguese(str string) string {
-	str, _ = format(str, Language_Portuguese, false)
+	str, _ = newFormatter(Language_Portuguese).format(str, false)
 	return str
 }
 
 func Generic(str string) string {
-	str, _ = format(str, Language_Generic, false)
+	str, _ = newFormatter(Language_Generic).format(str, false)
 	return str
 }
 
 func Author(str string, language uint8) (string, *AuthorStruct) {
-	return format(str, language, true)
+	return newFormatter(language).format(str, true)
+}
+
+func (f *Formatter) format(str string, formatAuthor bool) (string, *AuthorStruct) {
+	return f.formatWords(str, formatAuthor, nil)
 }
 
-func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruct) {
+// If acronyms is not nil then the words uppercased by the acronym heuristics are appended to it
+func (f *Formatter) formatWords(str string, formatAuthor bool, acronyms *[]Acronym) (string, *AuthorStruct) {
 
 	if len(str) == 0 {
 		return ``, nil
 	}
-	var small binsearch.KeyRunes
-	switch language {
-		case Language_English: small = englishSmall
-		case Language_French: small = frenchSmall
-		case Language_German: small = germanSmall
-		case Language_Italian: small = italianSmall
-		case Language_Spanish: small = spanishSmall
-		case Language_Portuguese: small = portugueseSmall
+	dict := f.dict
+	language := f.opt.Language
+	sentence := f.opt.Case == Case_Sentence && !formatAuthor
+	mixed := isMixedCase(str)
+	preserve := f.opt.PreserveMixedCase && mixed
+	threshold := f.opt.AcronymThreshold
+	if threshold <= 0 {
+		threshold = DefaultAcronymThreshold
+	}
+	var small keyList
+	if int(language) < len(dict.small) {
+		small = dict.small[language]
+	}
+	var hyphen uint8
+	if language == Language_English && f.opt.Style > Style_Default && int(f.opt.Style) < len(styles) {
+		small = styles[f.opt.Style].small
+		hyphen = styles[f.opt.Style].hyphen
 	}
 	
 	// Preprocessing
@@ -523,15 +664,17 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	b = bytes.Replace(b, []byte("—"), []byte(" — "), -1) // Separate out em dashes
 	b = bytes.Replace(b, []byte(" - "), []byte(" — "), -1) // Correct hyphens to em dashes
 	b = bytes.Replace(b, []byte("[microform]"), []byte(""), -1)
-	b = bytes.Trim(b, ` ;:.,`)
-	if len(b) == 0 {
-		return ``, nil
-	}
-	if b[0] == '(' {
-		b = removeBytes(b, '(', ')')
-	}
-	if b[0] == '[' {
-		b = removeBytes(b, '[', ']')
+	if f.opt.Punctuation == Punctuation_Clean {
+		b = bytes.Trim(b, ` ;:.,`)
+		if len(b) == 0 {
+			return ``, nil
+		}
+		if b[0] == '(' {
+			b = removeBytes(b, '(', ')')
+		}
+		if b[0] == '[' {
+			b = removeBytes(b, '[', ']')
+		}
 	}
 
 	n := len(b)
@@ -541,10 +684,10 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	
 	// Load all into struct
 	var r rune
-	var i, w int
+	var i, i2, w int
 	//var isnumeric bool
 	words := make([]wordStruct, 0, 4)
-	word := newRuneBuf()
+	word := newRuneBuf(dict)
     for i=0; i<n; i+=w {
         r, w = utf8.DecodeRune(b[i:])
 		// Parse spacers
@@ -554,6 +697,18 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			}
 			continue
 		}
+		// URLs, domain names and e-mail addresses are kept together as one word
+		if word.len == 0 {
+			for i2=i; i2<n && b[i2] > 32; i2++ {}
+			if dict.isAtomic(b[i:i2]) {
+				for _, r = range string(b[i:i2]) {
+					word.write(r)
+				}
+				word.atomic = true
+				i, w = i2, 0
+				continue
+			}
+		}
 		switch r {
 			case '-':
 				if word.len > 0 {
@@ -565,8 +720,14 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 					words = word.add(words, 3)
 					continue
 				}
-			case '[', '{': r = '('
-			case ']', '}': r = ')'
+			case '[', '{':
+				if f.opt.Punctuation == Punctuation_Clean {
+					r = '('
+				}
+			case ']', '}':
+				if f.opt.Punctuation == Punctuation_Clean {
+					r = ')'
+				}
 		}
 		word.write(r)
 	}
@@ -583,7 +744,8 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	words[0].isStart = true
 	for i=1; i<l; i++ {
 		if words[i-1].isEnd {
-			if words[i-1].puncAfter[0] != ',' {
+			// The end of a URL, domain name or e-mail address is not the end of a sentence, e.g. See https://example.com/ for more
+			if words[i-1].puncAfter[0] != ',' && (!words[i-1].isAtomic || endsSentence(words[i-1].puncAfter)) {
 				words[i].isStart = true
 			}
 		}
@@ -591,10 +753,28 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	words[l-1].isEnd = true
 	
 	// On authors, delete the first word if it is "by" or "the"
+	var corporate, familyFirst, inverted bool
 	if formatAuthor {
 		if equal(words[0].content, []rune("by")) || equal(words[0].content, []rune("the")) {
 			words[0].content = make([]rune, 0)
 		}
+		if corporate = f.isCorporate(contentsWithCommas(words)); !corporate {
+			f.markAuthorParts(words)
+			familyFirst = f.isFamilyFirst(words)
+			inverted = f.isInverted(words)
+		}
+	}
+	
+	// In sentence case, words typed with capitals in mixed case input are kept, as they are likely names, unless every word was capitalized
+	keepCaps := sentence && mixed && !isTitleCased(words)
+	
+	// The period of an abbreviation does not start a new sentence, e.g. United States. Dept. of Agriculture, or the Ph.D. program
+	if corporate || sentence {
+		for i=1; i<l; i++ {
+			if words[i].isStart && dict.isAbbreviation(&words[i-1]) {
+				words[i].isStart = false
+			}
+		}
 	}
 	
 	// Loop through all and apply rules
@@ -611,19 +791,53 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			continue
 		}
 		
-		if ws.isHonor {
+		// Pseudonym markers are left lowercase, including i.e. which is otherwise an honor
+		if ws.part == partPseudonym {
+			ws.content = lowerKey(string(content)) // a copy, since the content of an honor is shared with the dictionary
+			continue
+		}
+		
+		if ws.isHonor || ws.isAtomic {
+			continue
+		}
+		
+		// Life dates in author names are left lowercase, except eras
+		if ws.part == partDates {
+			switch string(content) {
+				case `bc`, `ad`, `bce`, `ce`: upperRune(content, -1)
+			}
+			continue
+		}
+		
+		// Relator terms are left lowercase, except at the start of the string, e.g. Hrsg. von Hans Müller, and so are the conjunctions between the names after a relator phrase
+		if ws.part == partRole || (ws.part == partOthers && f.conjunction(string(content)) != sepEnd) {
+			if i == 0 && ws.part == partRole {
+				upperRune(content, 0)
+			}
+			continue
+		}
+		
+		// Keep words with intentional mixed case as they were typed, and in titles words typed in uppercase, e.g. NATO, but not SMITH, John
+		if preserve && (hasInternalCapital(ws.original) || (!formatAuthor && isAllUpper(ws.original))) {
+			ws.content = ws.original
+			continue
+		}
+		
+		// Words that have their own casing
+		if id, ok := dict.display.Find(content); ok {
+			ws.content = append([]rune(nil), dict.display.format[id]...)
 			continue
 		}
 		
-		// Uppercase roman numerals
-		if isRoman(content) {
+		// Uppercase roman numerals, except in family-first names or as the only given name, where they are names, e.g. Kim Jong Il or Zhang, Xi
+		if dict.isRoman(content) && !(formatAuthor && (familyFirst || f.isOnlyGivenName(words, i))) {
 			ws.isRoman = true
 			upperRune(content, -1) // -1 means uppercase all
 			continue
 		}
 		
 		// Titles
-		if _, ok = titlesabv.Find(content); ok {
+		if _, ok = dict.titlesabv.Find(content); ok {
 			upperRune(content, 0)
 			ws.isTitle = true
 			// Ensure title is followed by a period
@@ -635,6 +849,15 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			continue
 		}
 		
+		// Generational suffixes, which are written with a period like titles but are not titles
+		if isGenerationAbbr(content) {
+			upperRune(content, 0)
+			if len(ws.puncAfter) == 0 || ws.puncAfter[0] != '.' {
+				ws.puncAfter = append([]rune("."), ws.puncAfter...)
+			}
+			continue
+		}
+		
 		// Check for McStuff
 		if ln > 3 {
 			if content[0] == 'm' && content[1] == 'c' {
@@ -674,12 +897,64 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			}
 		}
 		
-		if _, ok = makecaps.Find(content); ok {
+		if _, ok = dict.makecaps.Find(content); ok {
 			upperRune(content, -1)
 			//replaceRune(ws.puncAfter, '.', ';')
 			continue
 		}
 		
+		// Guess whether words that are not in the dictionaries are acronyms
+		if f.opt.Acronyms {
+			if _, ok = small.Find(content); !ok {
+				if heuristics, confidence := acronymScore(content, ws.original, language, mixed); confidence >= threshold {
+					upperRune(content, -1)
+					if acronyms != nil {
+						*acronyms = append(*acronyms, Acronym{string(content), heuristics, confidence})
+					}
+					continue
+				}
+			}
+		}
+		
+		// Sentence case only capitalizes the first word of each sentence, initials, words typed with capitals, and the English pronoun I in contractions
+		if sentence {
+			if keepCaps && isAllUpper(ws.original) {
+				upperRune(ws.content, -1)
+			} else if ws.isStart || (ln == 1 && len(ws.puncAfter) > 0 && ws.puncAfter[0] == '.') || (keepCaps && unicode.IsUpper(ws.original[0])) {
+				upperRune(ws.content, 0)
+			} else if language == Language_English && ln > 1 && content[0] == 'i' && (content[1] == 39 || content[1] == '’') {
+				upperRune(content, 0)
+			}
+			continue
+		}
+		
+		// Words following a hyphen, depending on the style
+		if hyphen != hyphenSmall && i > 0 && words[i-1].spaceAfter == 2 && !ws.isStart {
+			if hyphen == hyphenAll {
+				upperRune(content, 0)
+				continue
+			}
+			if isPrefix(lowerKey(string(words[i-1].content))) {
+				continue
+			}
+		}
+		
+		// Surname particles, e.g. Ludwig van der Rohe, Karl-Theodor von und zu Guttenberg
+		if formatAuthor && !familyFirst && f.isParticle(words, i) && (!ws.isEnd || inverted) {
+			f.particleCase(words, i)
+			continue
+		}
+		
+		// Arabic articles are lowercase within a name or title, e.g. Abd al-Rahman
+		if ws.spaceAfter == 2 && !ws.isStart && isArticle(content, formatAuthor) {
+			continue
+		}
+		
+		// ibn, bin and ben are lowercase after a given name, e.g. Osama bin Laden
+		if formatAuthor && !ws.isStart && i > 0 && len(words[i-1].content) > 0 && words[i-1].spaceAfter == 1 && ws.spaceAfter == 1 && f.isPatronymicAt(words, i) {
+			continue
+		}
+		
 		// Beginning and ending words need to be capitalized regardless of what they are
 		if ws.isStart || ws.isEnd {
 			upperRune(content, 0)
@@ -703,13 +978,6 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			continue
 		}
 		
-		// If this is an author check then also check multi-country lowercasings that occur in surnames
-		if formatAuthor {
-			if _, ok = multilast.Find(content); ok {
-				continue
-			}
-		}
-		
 		// Uppercase the first rune if none of the previous rules applied
 		//replaceRune(ws.puncAfter, '.', ';')
 		upperRune(content, 0)
@@ -722,6 +990,9 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 		ws = &words[i]
 		ln = len(ws.content)
 		if ln == 0 {
+			if len(ws.original) == 0 && string(ws.puncBefore) == `&` { // an ampersand on its own, e.g. Procter & Gamble
+				buf.WriteString(`& `)
+			}
 			continue
 		}
 		for _, r = range ws.puncBefore {
@@ -733,7 +1004,7 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 		// If formatAuthor then add period after individual letters that are uppercase
 		if formatAuthor {
 			if ln == 1 {
-				if len(ws.puncAfter) == 0 && !unicode.IsLower(ws.content[0]) {
+				if len(ws.puncAfter) == 0 && !unicode.IsLower(ws.content[0]) && ws.part != partRole {
 					ws.puncAfter = []rune{'.'}
 				}
 			}
@@ -757,10 +1028,16 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	}
 		
 	author := new(AuthorStruct)
+	author.language = language
+	if corporate {
+		author.Corporate = bufString
+		return bufString, author
+	}
+	f.extractAuthorParts(words, author)
 	
-	// Find author's title
+	// Find author's title, which is with the given names in an inverted name, e.g. Scott, Sir Walter but not San Martin, José
 	var going bool
-	for i=0; i<l; i++ {
+	for i=f.givenNamesStart(words); i<l; i++ {
 		ws = &words[i]
 		content = ws.content
 		ln = len(content)
@@ -781,20 +1058,21 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			}
 			ws.content = content[0:0]
 			going = true
-		} else {
-			if _, ok = titles.Find(content); ok {
+		} else if n := f.titleWords(words, i); n > 0 {
+			for j := i; j < i + n; j++ {
 				if len(author.Title) == 0 {
-					author.Title = string(content)
+					author.Title = string(words[j].content)
 				} else {
-					switch words[i-1].spaceAfter {
-						case 1: author.Title += ` ` + string(content)
-						case 2: author.Title += `-` + string(content)
-						case 3: author.Title += `/` + string(content)
+					switch words[j-1].spaceAfter {
+						case 1: author.Title += ` ` + string(words[j].content)
+						case 2: author.Title += `-` + string(words[j].content)
+						case 3: author.Title += `/` + string(words[j].content)
 					}
 				}
-				ws.content = content[0:0]
-				going = true
+				words[j].content = words[j].content[0:0]
 			}
+			i += n - 1
+			going = true
 		}
 		
 		if !going {
@@ -802,6 +1080,8 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 		}
 	}
 	
+	f.extractGenerations(words, author)
+	
 	// Find author's suffix & check for comma in puncAfter
 	var comma int
 	for i=0; i<l; i++ {
@@ -859,6 +1139,25 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	
 	// Get first and last name if there is a comma
 	if comma > 0 {
+		// Particles after the given names belong to the surname, e.g. Rohe, Ludwig van der
+		end := l
+		for end > comma && (len(words[end-1].content) == 0 || f.isParticle(words, end-1)) {
+			end--
+		}
+		for i=comma; i<end && len(words[i].content) == 0; i++ {}
+		if i == end { // there are no given names
+			end = l
+		}
+		for i=end; i<l; i++ {
+			ws = &words[i]
+			if len(ws.content) == 0 {
+				continue
+			}
+			for _, r = range ws.content {
+				last.WriteRune(r)
+			}
+			last.WriteByte(' ')
+		}
 		for i=0; i<comma; i++ {
 			ws = &words[i]
 			if len(ws.content) == 0 {
@@ -884,7 +1183,7 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 			}
 		}
 	Out1:
-		for ; i<l; i++ {
+		for ; i<end; i++ {
 			ws = &words[i]
 			if len(ws.content) == 0 {
 				continue
@@ -899,12 +1198,17 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 				first.WriteRune(r)
 			}
 			switch ws.spaceAfter {
-				case 1: i++; break Out1
+				case 1:
+					if isKunya(lowerKey(string(ws.content))) { // Abu Bakr
+						first.WriteByte(' ')
+						continue
+					}
+					i++; break Out1
 				case 2: first.WriteByte('-')
 				case 3: first.WriteByte('/')
 			}
 		}
-		for ; i<l; i++ {
+		for ; i<end; i++ {
 			ws = &words[i]
 			if len(ws.content) == 0 {
 				continue
@@ -924,6 +1228,37 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 				case 3: middle.WriteByte('/')
 			}
 		}
+	} else if familyFirst {
+		// Get last name then first name, e.g. Mao Zedong
+		author.FamilyFirst = true
+		buf = last
+		for i=0; i<l; i++ {
+			ws = &words[i]
+			if len(ws.content) == 0 {
+				continue
+			}
+			for _, r = range ws.puncBefore {
+				buf.WriteRune(r)
+			}
+			for _, r = range ws.content {
+				buf.WriteRune(r)
+			}
+			for _, r = range ws.puncAfter {
+				buf.WriteRune(r)
+			}
+			switch ws.spaceAfter {
+				case 1:
+					if buf == last {
+						buf = first
+					} else if buf == first {
+						buf = middle
+					} else {
+						buf.WriteByte(' ')
+					}
+				case 2: buf.WriteByte('-')
+				case 3: buf.WriteByte('/')
+			}
+		}
 	} else {
 		// Get first and last name if there is no comma
 		going = true
@@ -933,14 +1268,17 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 				continue
 			}
 			if going && ws.isRoman {
-				i--
 				continue
 			}
 			if ws.spaceAfter > 1 {
 				going = false
 				continue
 			}
-			if _, ok = multilast.Find(ws.content); ok {
+			if f.isParticle(words, i) {
+				going = equal(ws.content, []rune("y")) || equal(ws.content, []rune("e")) // Ortega y Gasset, both are surnames
+				continue
+			}
+			if f.isPatronymicAt(words, i) || isKunya(lowerKey(string(ws.content))) {
 				going = false
 				continue
 			}
@@ -956,6 +1294,9 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 		if lastpos < 0 {
 			lastpos = 0
 		}
+		if language == Language_Spanish || language == Language_Portuguese {
+			lastpos = f.secondSurname(words, lastpos)
+		}
 		for i=lastpos; i<l; i++ {
 			ws = &words[i]
 			if len(ws.content) == 0 {
@@ -994,7 +1335,12 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 				first.WriteRune(r)
 			}
 			switch ws.spaceAfter {
-				case 1: i++; break Out2
+				case 1:
+					if isKunya(lowerKey(string(ws.content))) { // Abu Bakr
+						first.WriteByte(' ')
+						continue
+					}
+					i++; break Out2
 				case 2: first.WriteByte('-')
 				case 3: first.WriteByte('/')
 			}
@@ -1024,6 +1370,7 @@ func format(str string, language uint8, formatAuthor bool) (string, *AuthorStruc
 	author.First = string(bytes.TrimRight(first.Bytes(), `, `))
 	author.Middle = string(bytes.TrimRight(middle.Bytes(), `, `))
 	author.Last = string(bytes.TrimRight(last.Bytes(), `, `))
+	completeDesignation(author)
 	first.Close()
 	middle.Close()
 	last.Close()
@@ -0,0 +1,137 @@
+package titlecase
+
+import (
+ "strings"
+ "testing"
+)
+
+func TestLongAtomicWords(t *testing.T) {
+	url := `https://example.com/` + strings.Repeat(`abcdefghij/`, 30) + `index.html`
+	email := strings.Repeat(`a`, 300) + `@example.com`
+	tests := []struct {
+		in, want string
+	}{
+		{`see ` + url + ` for more`, `See ` + url + ` for More`},
+		{`write to ` + email + ` today`, `Write to ` + email + ` Today`},
+	}
+	for _, test := range tests {
+		if got := English(test.in); got != test.want {
+			t.Errorf("English(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestAtomicWords(t *testing.T) {
+	sentence := New(Options{Language: Language_English, Case: Case_Sentence})
+	tests := []struct {
+		in, title, sentence string
+	}{
+		{`See https://example.com/ for more`, `See https://example.com/ for More`, `See https://example.com/ for more`},
+		{`see (https://example.com/) for more`, `See (https://example.com/) for More`, `See (https://example.com/) for more`},
+		{`see https://Example.com/Some/Path for more`, `See https://example.com/Some/Path for More`, `See https://example.com/Some/Path for more`},
+		{`email info@example.com for more`, `Email info@example.com for More`, `Email info@example.com for more`},
+		{`write to "info@example.com" for more`, `Write to "info@example.com" for More`, `Write to "info@example.com" for more`},
+		{`visit www.example.com for more`, `Visit www.example.com for More`, `Visit www.example.com for more`},
+		{`visit Example.com for more`, `Visit example.com for More`, `Visit example.com for more`},
+		{`visit example.com, then go`, `Visit example.com, Then Go`, `Visit example.com, then go`},
+		{`visit example.com. then go`, `Visit example.com. Then Go`, `Visit example.com. Then go`},
+		{`is it example.com? or not`, `Is It example.com? Or Not`, `Is it example.com? Or not`},
+		{`see https://example.com/a/b: the details`, `See https://example.com/a/b: The Details`, `See https://example.com/a/b: The details`},
+	}
+	for _, test := range tests {
+		if got := English(test.in); got != test.title {
+			t.Errorf("English(%q) = %q, want %q", test.in, got, test.title)
+		}
+		if got := sentence.Format(test.in); got != test.sentence {
+			t.Errorf("sentence case %q = %q, want %q", test.in, got, test.sentence)
+		}
+	}
+}
+
+func TestDisplayForms(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in, want string
+	}{
+		{Language_English, `my iphone and ebay account`, `My iPhone and eBay Account`},
+		{Language_English, `the nasa and bbc archives`, `The NASA and BBC Archives`},
+		{Language_German, `die acme gmbh`, `Die Acme GmbH`},
+		{Language_Italian, `il bambino nato ieri`, `Il Bambino Nato Ieri`},
+		{Language_Portuguese, `um brasileiro nato`, `Um Brasileiro Nato`},
+		{Language_Spanish, `la cia de seguros`, `La Cia de Seguros`},
+	}
+	for _, test := range tests {
+		if got, _ := newFormatter(test.language).format(test.in, false); got != test.want {
+			t.Errorf("language %d: %q = %q, want %q", test.language, test.in, got, test.want)
+		}
+	}
+}
+
+func TestPreserveMixedCase(t *testing.T) {
+	f := New(Options{Language: Language_English, PreserveMixedCase: true})
+	tests := []struct {
+		in, want string
+	}{
+		{`my iPhone and eBay account`, `My iPhone and eBay Account`},
+		{`typesetting with LaTeX`, `Typesetting With LaTeX`},
+		{`McGraw-Hill guide to JavaScript`, `McGraw-Hill Guide to JavaScript`},
+		{`the NATO summit in brussels`, `The NATO Summit in Brussels`},
+		{`World War II and the UN`, `World War II and the UN`},
+		{`THE NATO SUMMIT`, `The Nato Summit`},
+		{`the nato summit`, `The Nato Summit`},
+		{`the iphone`, `The iPhone`},
+	}
+	for _, test := range tests {
+		if got := f.Format(test.in); got != test.want {
+			t.Errorf("%q = %q, want %q", test.in, got, test.want)
+		}
+	}
+	author := New(Options{Language: Language_English, Author: true, PreserveMixedCase: true})
+	if got, _ := author.Author(`SMITH, John`); got != `Smith, John` {
+		t.Errorf("%q = %q, want %q", `SMITH, John`, got, `Smith, John`)
+	}
+}
+
+func TestCapsAndRomanNumerals(t *testing.T) {
+	tests := []struct {
+		language uint8
+		in, want string
+	}{
+		{Language_English, `nasa, unesco and unicef reports`, `NASA, UNESCO and UNICEF Reports`},
+		{Language_English, `the fbi files on ibm`, `The FBI Files on IBM`},
+		{Language_English, `dna evidence at the bbc`, `DNA Evidence at the BBC`},
+		{Language_English, `isbn numbers`, `ISBN Numbers`},
+		{Language_Italian, `la nasa e la bbc`, `La NASA e la BBC`},
+		{Language_English, `louis xiv and il trovatore`, `Louis XIV and Il Trovatore`},
+		{Language_Italian, `il nome della rosa`, `Il Nome della Rosa`},
+		{Language_Italian, `papa pio ii`, `Papa Pio II`},
+	}
+	for _, test := range tests {
+		if got, _ := newFormatter(test.language).format(test.in, false); got != test.want {
+			t.Errorf("language %d: %q = %q, want %q", test.language, test.in, got, test.want)
+		}
+	}
+}
+
+func TestSentenceCaseAbbreviations(t *testing.T) {
+	f := New(Options{Language: Language_English, Case: Case_Sentence})
+	tests := []struct {
+		in, want string
+	}{
+		{`what i did in the ph.d. program at nasa`, `What I did in the Ph.D. program at NASA`},
+		{`A letter to Dr. Watson. then the reply`, `A letter to Dr. Watson. Then the reply`},
+		{`The works of J. R. R. Tolkien`, `The works of J. R. R. Tolkien`},
+		{`Travels in London and Paris`, `Travels in London and Paris`},
+		{`A history of NATO`, `A history of NATO`},
+		{`TRAVELS IN THE NORTH`, `Travels in the north`},
+		{`Travels In The North`, `Travels in the north`},
+	}
+	for _, test := range tests {
+		if got := f.Format(test.in); got != test.want {
+			t.Errorf("%q = %q, want %q", test.in, got, test.want)
+		}
+	}
+	if got := f.Format(`Life in the U.S.A. today`); !strings.HasSuffix(got, ` today`) {
+		t.Errorf("%q = %q, want today in lowercase", `Life in the U.S.A. today`, got)
+	}
+}
@@ -0,0 +1,178 @@
+package titlecase
+
+import (
+ "bytes"
+ "unicode"
+ "unicode/utf8"
+)
+
+// Top-level domains that are recognized in domain names
+var genericTLD = []string{`com`, `org`, `net`, `edu`, `gov`, `mil`, `int`, `info`, `biz`, `io`, `co`, `ai`, `app`, `dev`, `tv`, `uk`, `ca`, `au`, `de`, `fr`, `es`, `pt`,
+ `nl`, `ch`, `se`, `dk`, `fi`, `ie`, `jp`, `cn`, `ru`, `br`, `mx`, `nz`, `za`, `eu`, `pl`, `cz`, `gr`, `ar`, `hu`, `ro`, `sk`, `kr`, `tw`, `hk`, `sg`, `ua`}
+
+// Top-level domains that are also common words, these are only recognized in domain names with at least 3 parts (e.g. www.example.it) to avoid mistaking a missing space for a domain
+var wordTLD = []string{`it`, `in`, `me`, `no`, `at`, `be`, `us`, `is`, `to`, `so`, `do`, `go`, `my`, `am`, `an`, `as`, `by`, `of`, `on`, `or`, `la`}
+
+// Removes punctuation from the beginning and end of a word
+func trimPunct(b []byte) []byte {
+	var r rune
+	var w int
+	for len(b) > 0 {
+		r, w = utf8.DecodeRune(b)
+		if !unicode.IsPunct(r) {
+			break
+		}
+		b = b[w:]
+	}
+	for len(b) > 0 {
+		r, w = utf8.DecodeLastRune(b)
+		if !unicode.IsPunct(r) {
+			break
+		}
+		b = b[0:len(b)-w]
+	}
+	return b
+}
+
+func hasPrefixFold(b []byte, prefix string) bool {
+	return len(b) >= len(prefix) && bytes.EqualFold(b[0:len(prefix)], []byte(prefix))
+}
+
+func isDomainLabel(b []byte) bool {
+	if len(b) == 0 || b[0] == '-' || b[len(b)-1] == '-' {
+		return false
+	}
+	for _, c := range b {
+		switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+				continue
+		}
+		return false
+	}
+	return true
+}
+
+// Whether b is a domain name, optionally followed by a path. If anyTLD is false then the top-level domain must be a known one.
+func isDomain(b []byte, anyTLD bool) bool {
+	if i := bytes.IndexAny(b, `/?#`); i > -1 {
+		b = b[0:i]
+	}
+	labels := bytes.Split(b, []byte{'.'})
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !isDomainLabel(label) {
+			return false
+		}
+	}
+	tld := labels[len(labels)-1]
+	if anyTLD {
+		if len(tld) < 2 {
+			return false
+		}
+		for _, c := range tld {
+			if c < 'A' || (c > 'Z' && c < 'a') || c > 'z' {
+				return false
+			}
+		}
+		return true
+	}
+	for _, t := range genericTLD {
+		if bytes.EqualFold(tld, []byte(t)) {
+			return true
+		}
+	}
+	if len(labels) > 2 {
+		for _, t := range wordTLD {
+			if bytes.EqualFold(tld, []byte(t)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isEmail(b []byte) bool {
+	i := bytes.IndexByte(b, '@')
+	if i < 1 || bytes.IndexByte(b[i+1:], '@') > -1 {
+		return false
+	}
+	for _, c := range b[0:i] {
+		if c <= 32 || c == '(' || c == ')' || c == ',' || c == ';' || c == ':' {
+			return false
+		}
+	}
+	return isDomain(b[i+1:], true)
+}
+
+func isURL(b []byte) bool {
+	for _, scheme := range []string{`http://`, `https://`, `ftp://`} {
+		if hasPrefixFold(b, scheme) {
+			return isDomain(b[len(scheme):], true)
+		}
+	}
+	if hasPrefixFold(b, `www.`) {
+		return isDomain(b, true)
+	}
+	return false
+}
+
+// Whether the text up to the next space should be kept together as one word, because it is a URL, domain name or e-mail address
+func (d *Dictionaries) isAtomic(b []byte) bool {
+	b = trimPunct(b)
+	if bytes.IndexByte(b, '.') == -1 {
+		return false
+	}
+	if _, ok := d.honor.Find(lowerKey(string(b))); ok { // B.A.Com is an honor, not a domain
+		return false
+	}
+	return isURL(b) || isEmail(b) || isDomain(b, false)
+}
+
+// Lowercases the scheme and host of a URL, domain name or e-mail address, leaving any path as it was typed
+func lowerAtomic(word []rune) {
+	var start int
+	for i := 0; i < len(word) - 2; i++ {
+		if word[i] == ':' && word[i+1] == '/' && word[i+2] == '/' {
+			start = i + 3
+			break
+		}
+	}
+	for i := 0; i < start; i++ {
+		word[i] = unicode.ToLower(word[i])
+	}
+	for i := start; i < len(word); i++ {
+		switch word[i] {
+			case '/', '?', '#':
+				return
+		}
+		word[i] = unicode.ToLower(word[i])
+	}
+}
+
+// Whether a word containing punctuation is a number with decimals or grouped thousands (3.5, 1,000,000), a time or ratio (10:30), or a version (2.0, v1.2.3)
+func isNumeric(word []rune) bool {
+	if len(word) > 1 && (word[0] == 'v' || word[0] == 'V') {
+		word = word[1:]
+	}
+	if len(word) == 0 {
+		return false
+	}
+	digit := false // whether the previous rune was a digit
+	for _, r := range word {
+		switch r {
+			case '.', ',', ':':
+				if !digit {
+					return false
+				}
+				digit = false
+			default:
+				if r < '0' || r > '9' {
+					return false
+				}
+				digit = true
+		}
+	}
+	return digit
+}